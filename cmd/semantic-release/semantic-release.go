@@ -1,28 +1,30 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 
+	"github.com/NeowayLabs/semantic-release/src/ci"
 	commitmessage "github.com/NeowayLabs/semantic-release/src/commit-message"
 	committype "github.com/NeowayLabs/semantic-release/src/commit-type"
+	"github.com/NeowayLabs/semantic-release/src/config"
+	"github.com/NeowayLabs/semantic-release/src/constants"
 	"github.com/NeowayLabs/semantic-release/src/files"
 	"github.com/NeowayLabs/semantic-release/src/git"
 	"github.com/NeowayLabs/semantic-release/src/log"
+	"github.com/NeowayLabs/semantic-release/src/release"
 	"github.com/NeowayLabs/semantic-release/src/semantic"
+	"github.com/NeowayLabs/semantic-release/src/semver"
+	"github.com/NeowayLabs/semantic-release/src/style"
 	"github.com/NeowayLabs/semantic-release/src/time"
 	v "github.com/NeowayLabs/semantic-release/src/version"
 )
 
-const (
-	serviceName = "semantic-release"
-	colorCyan   = "\033[36m"
-	colorGreen  = "\033[32m"
-	colorRed    = "\033[31m"
-	colorYellow = "\033[33m"
-	colorReset  = "\033[0m"
-)
+const serviceName = "semantic-release"
 
 var (
 	// version is set at build time
@@ -31,6 +33,8 @@ var (
 )
 
 func main() {
+	style.Init(false)
+
 	version := false
 	flag.BoolVar(&version, "version", false, "Show version")
 	flag.Parse()
@@ -40,73 +44,175 @@ func main() {
 	helpCommitCmd := flag.NewFlagSet("help-cmt", flag.ExitOnError)
 
 	commitLint := upgradeVersionCmd.Bool("commit-lint", false, "Only lint commit history if set as true. (default false)")
+	dryRun := upgradeVersionCmd.Bool("dry-run", false, "Compute the new release and print the changes it would make without committing, pushing or tagging. (default false)")
 	branchName := upgradeVersionCmd.String("branch-name", "", "Branch name to be cloned.")
+	repoPath := upgradeVersionCmd.String("repo-path", "", "Path to an already checked out repository. When set, the repository is not cloned and git host, group and project default to the origin remote.")
 	gitHost := upgradeVersionCmd.String("git-host", "", "Git host name. I.e.: gitlab.integration-tests.com. (required)")
 	groupName := upgradeVersionCmd.String("git-group", "", "Git group name. (required)")
 	projectName := upgradeVersionCmd.String("git-project", "", "Git project name. (required)")
 	upgradePyFile := upgradeVersionCmd.Bool("setup-py", false, "Upgrade version in setup.py file. (default false)")
+	upgradePyprojectFile := upgradeVersionCmd.Bool("pyproject", false, "Upgrade version in the [project] or [tool.poetry] table of the pyproject.toml file. (default false)")
+	configPath := upgradeVersionCmd.String("config", "", "Path to the configuration file. (default .semantic-release.yml at the repository root)")
+	tagFormat := upgradeVersionCmd.String("tag-format", "", "Template of the release tags. It must contain {version} once. I.e.: v{version}. Overrides the configuration file. (default {version})")
+	legacyTags := upgradeVersionCmd.Bool("legacy-tags", false, "Also consider bare tags, such as 1.0.0, as releases when migrating to a new tag format. (default false)")
+	changelogPath := upgradeVersionCmd.String("changelog", "", "Path to the changelog file relative to the repository root. Overrides the configuration file. (default CHANGELOG.md)")
 	username := upgradeVersionCmd.String("username", "", "Git username. (required)")
 	password := upgradeVersionCmd.String("password", "", "Git password. (required)")
-	logLevel := upgradeVersionCmd.String("log-level", "debug", "Log level.")
+	token := upgradeVersionCmd.String("token", "", "Access token used to clone and push over HTTPS. -username is optional along with it. Prefer the SEMANTIC_RELEASE_TOKEN environment variable.")
+	tokenFile := upgradeVersionCmd.String("token-file", "", "Path to a file containing the access token.")
+	bearer := upgradeVersionCmd.Bool("bearer", false, "Send the access token in an Authorization: Bearer header instead of using basic auth. (default false)")
+	jobToken := upgradeVersionCmd.String("job-token", "", "GitLab CI job token. I.e.: ${CI_JOB_TOKEN}")
+	netrc := upgradeVersionCmd.Bool("netrc", false, "Read the credentials of the git host from the netrc file in NETRC or ~/.netrc. (default false)")
+	credentialHelper := upgradeVersionCmd.Bool("credential-helper", false, "Read the credentials of the git host from the configured git credential helpers. (default false)")
+	sshKey := upgradeVersionCmd.String("ssh-key", "", "Path to the SSH private key used to clone and push over SSH instead of HTTPS.")
+	sshKeyPassphrase := upgradeVersionCmd.String("ssh-key-passphrase", "", "Passphrase of the SSH private key. Prefer the SEMANTIC_RELEASE_SSH_KEY_PASSPHRASE environment variable.")
+	sshAgent := upgradeVersionCmd.Bool("ssh-agent", false, "Clone and push over SSH with the keys of the running ssh-agent. (default false)")
+	sshUser := upgradeVersionCmd.String("ssh-user", "git", "SSH user name.")
+	sshKnownHosts := upgradeVersionCmd.String("ssh-known-hosts", "", "Path to the known_hosts file used to verify the host key. (default ~/.ssh/known_hosts)")
+	caBundle := upgradeVersionCmd.String("ca-bundle", "", "Path to a PEM file with certificate authorities trusted along with the system ones. I.e.: the private CA of a self-hosted git server.")
+	clientCert := upgradeVersionCmd.String("client-cert", "", "Path to the PEM client certificate presented to git servers that require mutual TLS.")
+	clientKey := upgradeVersionCmd.String("client-key", "", "Path to the PEM key of the client certificate.")
+	insecureSkipTLSVerify := upgradeVersionCmd.Bool("insecure-skip-tls-verify", false, "Skip the verification of the git server certificate. Only use it for testing. (default false)")
+	releaseProvider := upgradeVersionCmd.String("release-provider", "", "Git hosting provider the release is published to after the tag is pushed: gitlab or github. Overrides the configuration file. (default no release is published)")
+	releaseAPIURL := upgradeVersionCmd.String("release-api-url", "", "Base URL of the provider API. Overrides the configuration file. (default https://<git-host>/api/v4 for gitlab, https://api.github.com or https://<git-host>/api/v3 for github)")
+	releaseToken := upgradeVersionCmd.String("release-token", "", "Token used to publish the release. Prefer the SEMANTIC_RELEASE_RELEASE_TOKEN environment variable. (default the git token or password)")
+	logLevel := upgradeVersionCmd.String("log-level", "debug", "Log level: debug, info, warn, error or fatal. Messages below it are not logged.")
+	noColor := upgradeVersionCmd.Bool("no-color", false, "Disable colors. They are also disabled when the NO_COLOR environment variable is set or the output is not a terminal. (default false)")
+	logFormat := upgradeVersionCmd.String("log-format", "text", "Log format: text or json, which writes one object per line with level, timestamp, component, message and fields.")
+	outputFormat := upgradeVersionCmd.String("output", "text", "Output format of the next-version command: text, json or env.")
 
 	if len(os.Args) < 2 {
 		printWelcomeMessage()
-		fmt.Println("\n" + colorRed + "Oops! Invalid input parameter." + colorCyan + " *** Usage: docker run neowaylabs/semantic-release [up] [help] [help-cmt] ***" + colorReset)
+		fmt.Println("\n" + style.Red + "Oops! Invalid input parameter." + style.Cyan + " *** Usage: docker run neowaylabs/semantic-release [up] [next-version] [help] [help-cmt] ***" + style.Reset)
+		os.Exit(1)
+	}
+
+	// the help commands need neither the git host nor credentials, so they run before any of them is set up
+	switch os.Args[1] {
+	case "up", "next-version", "help-cmt":
+	case "help":
+		printWelcomeMessage()
+		printMainCommands()
+		helpCmd.PrintDefaults()
+		upgradeVersionCmd.PrintDefaults()
+		return
+	default:
+		printWelcomeMessage()
+		fmt.Printf(style.Red+"\nOops! Invalid input parameter [%v]. Expected [up], [next-version], [help] or [help-cmt]."+style.Reset+" \nRun "+style.Cyan+"[docker run neowaylabs/semantic-release help`] "+style.Reset+"to learn more about this CLI usage.\n", os.Args[1])
 		os.Exit(1)
 	}
 
 	upgradeVersionCmd.Parse(os.Args[2:])
 
+	if err := ci.SetFlagsFromEnvironment(upgradeVersionCmd, os.Getenv); err != nil {
+		fmt.Println(style.Red + "Oops! " + err.Error() + style.Reset)
+		os.Exit(1)
+	}
+
+	style.Init(*noColor)
+
 	if Version == "No version provided at build time" {
 		Version = ""
 	}
 
 	logger, err := log.New(serviceName, Version, *logLevel)
 	if err != nil {
+		fmt.Println(style.Red + "Oops! " + err.Error() + style.Reset)
 		os.Exit(1)
 	}
 
-	printWelcomeMessage()
-	switch os.Args[1] {
-	case "up":
-		logger.Info(colorYellow + "\nSemantic Version just started the process...\n\n" + colorReset)
-
-		semantic := newSemantic(logger, upgradeVersionCmd, gitHost, groupName, projectName, username, password, upgradePyFile, branchName)
+	if err := logger.SetFormat(*logFormat); err != nil {
+		fmt.Println(style.Red + "Oops! " + err.Error() + style.Reset)
+		os.Exit(1)
+	}
 
-		if *commitLint {
-			if *branchName == "" {
-				logger.Error(colorRed + "\nThe argument -branch-name must be set when --commit-lint is true.\n\n" + colorReset)
-			}
+	// logs are written to stderr, so their colors are decided from it, while the help texts written to stdout follow style.Init
+	logger.SetColor(style.ShouldColor(*noColor, os.Getenv, style.IsTerminal(os.Stderr)))
 
-			logger.Info(colorYellow + "\nSemantic Version commit lint started...\n\n" + colorReset)
-			err := semantic.CommitLint()
-			if err != nil {
-				printCommitTypes()
-				printCommitMessageExample()
-				os.Exit(1)
-			}
-		} else {
-			if err := semantic.GenerateNewRelease(); err != nil {
-				logger.Error(err.Error())
-				os.Exit(1)
-			}
+	if os.Args[1] == "help-cmt" {
+		if *logFormat != log.JSONFormat {
+			printWelcomeMessage()
 		}
-
-		logger.Info(colorYellow + "\nDone!" + colorReset)
-	case "help":
-		printMainCommands()
-		helpCmd.PrintDefaults()
-		upgradeVersionCmd.PrintDefaults()
-
-	case "help-cmt":
 		helpCommitCmd.PrintDefaults()
 		printCommitMessageExample()
-		printCommitTypes()
+		// the commit types may be declared in the configuration file of the repository
+		printCommitTypes(newCommitTypeManager(logger, loadConfig(logger, configPath, *repoPath)))
+		return
+	}
 
-	default:
-		fmt.Printf(colorRed+"\nOops! Invalid input parameter [%v]. Expected [up], [help] or [help-cmt]."+colorReset+" \nRun "+colorCyan+"[docker run neowaylabs/semantic-release help`] "+colorReset+"to learn more about this CLI usage.\n", os.Args[1])
+	fillFromCI(logger, gitHost, groupName, projectName, branchName, *commitLint)
+
+	// the secrets are registered before anything else is logged
+	logger.AddSecrets(*password, *token, *jobToken, *sshKeyPassphrase, *releaseToken)
+
+	if *insecureSkipTLSVerify {
+		logger.Warn("TLS verification is disabled. The git server certificate will not be verified, so credentials may be sent to an impersonator.")
+	}
+
+	tlsConfig := &git.TLSConfig{CABundlePath: *caBundle, ClientCertPath: *clientCert, ClientKeyPath: *clientKey, Insecure: *insecureSkipTLSVerify}
+	if err := git.InstallTLS(tlsConfig); err != nil {
+		logger.Error(err.Error())
 		os.Exit(1)
 	}
+
+	// with -repo-path the git host may be inferred from the remote, which netrc and credential helpers need to look the credentials up
+	resolveCredentials := func() (git.Auth, error) {
+		credentials, err := newCredentials(gitHost, username, password, token, tokenFile, jobToken, bearer, netrc, credentialHelper, newSSHAuth(sshUser, sshKey, sshKeyPassphrase, sshKnownHosts, sshAgent))
+		if err != nil {
+			return nil, err
+		}
+		registerSecrets(logger, credentials)
+		return credentials, nil
+	}
+
+	if os.Args[1] == "next-version" {
+		// the output of this command is meant to be parsed by scripts, so nothing but the version goes to stdout
+		semantic, _ := newSemantic(logger, upgradeVersionCmd, gitHost, groupName, projectName, resolveCredentials, tlsConfig, upgradePyFile, upgradePyprojectFile, branchName, repoPath, dryRun, configPath, changelogPath, tagFormat, legacyTags, releaseProvider, releaseAPIURL, releaseToken, false)
+
+		newVersion, upgradeType, err := semantic.GetNextVersion()
+		if err != nil {
+			logger.Error(err.Error())
+			os.Exit(1)
+		}
+
+		if err := printNextVersion(*outputFormat, newVersion, upgradeType); err != nil {
+			logger.Error(err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
+	// in json format the output is meant to be parsed, so the banner is not printed
+	if *logFormat != log.JSONFormat {
+		printWelcomeMessage()
+	}
+
+	logger.Info("Semantic Version just started the process...")
+
+	// only an actual release is published, so linting or previewing it needs no provider token
+	publishRelease := !*commitLint && !*dryRun
+	semantic, commitTypeManager := newSemantic(logger, upgradeVersionCmd, gitHost, groupName, projectName, resolveCredentials, tlsConfig, upgradePyFile, upgradePyprojectFile, branchName, repoPath, dryRun, configPath, changelogPath, tagFormat, legacyTags, releaseProvider, releaseAPIURL, releaseToken, publishRelease)
+
+	if *commitLint {
+		if *branchName == "" {
+			logger.Error("The argument -branch-name must be set when --commit-lint is true.")
+		}
+
+		logger.Info("Semantic Version commit lint started...")
+		err := semantic.CommitLint()
+		if err != nil {
+			printCommitTypes(commitTypeManager)
+			printCommitMessageExample()
+			os.Exit(1)
+		}
+	} else {
+		if err := semantic.GenerateNewRelease(); err != nil {
+			logger.Error(err.Error())
+			os.Exit(1)
+		}
+	}
+
+	logger.Info("Done!")
 }
 
 type UpgradeFiles struct {
@@ -117,109 +223,504 @@ type UpgradeFile struct {
 	Path            string
 	DestinationPath string
 	VariableName    string
+	Occurrence      int
+	Type            string
+	Pattern         string
+	Matches         int
+	YAMLPaths       []string
 }
 
-func addFilesToUpgradeList(upgradePyFile *bool, repositoryRootPath string) UpgradeFiles {
+func addFilesToUpgradeList(upgradePyFile, upgradePyprojectFile *bool, repositoryRootPath string, versionFiles []config.VersionFile) UpgradeFiles {
 	upgradeFilesList := UpgradeFiles{}
+	for _, versionFile := range versionFiles {
+		upgradeFilesList.Files = append(upgradeFilesList.Files, UpgradeFile{Path: resolvePath(repositoryRootPath, versionFile.Path), DestinationPath: "", VariableName: versionFile.Variable, Occurrence: versionFile.Occurrence, Type: versionFile.Type, Pattern: versionFile.Pattern, Matches: versionFile.Matches, YAMLPaths: versionFile.YAMLPaths})
+	}
+
 	if *upgradePyFile {
 		upgradeFilesList.Files = append(upgradeFilesList.Files, UpgradeFile{Path: fmt.Sprintf("%s/setup.py", repositoryRootPath), DestinationPath: "", VariableName: "__version__"})
 	}
 
+	if *upgradePyprojectFile {
+		upgradeFilesList.Files = append(upgradeFilesList.Files, UpgradeFile{Path: fmt.Sprintf("%s/pyproject.toml", repositoryRootPath), DestinationPath: "", Type: constants.UpgradeTypePyproject})
+	}
+
 	return upgradeFilesList
 }
 
-func validateIncomingParams(logger *log.Log, upgradeVersionCmd *flag.FlagSet, gitHost, groupName, projectName, username, password *string, upgradePyFile *bool) {
+// resolvePath returns path as is when it is absolute, otherwise relative to the repository root path.
+func resolvePath(repositoryRootPath, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(repositoryRootPath, path)
+}
+
+// newCommitTypeManager returns the commit types of the configuration file, or the default ones when it declares none.
+func newCommitTypeManager(logger *log.Log, cfg *config.Config) *committype.CommitType {
+	commitTypeManager := committype.New(logger.WithComponent("commit-type"))
+	if len(cfg.CommitTypes) > 0 {
+		commitTypes := cfg.GetCommitTypesByBump()
+		commitTypeManager.Configure(commitTypes[config.BumpMajor], commitTypes[config.BumpMinor], commitTypes[config.BumpPatch], commitTypes[config.BumpSkip])
+	}
+	return commitTypeManager
+}
+
+// loadConfig reads the configuration file. The default file is optional, but an informed one must exist.
+func loadConfig(logger *log.Log, configPath *string, repositoryRootPath string) *config.Config {
+	path := *configPath
+	if path == "" {
+		path = config.DefaultFileName
+	}
+
+	cfg, err := config.Load(resolvePath(repositoryRootPath, path))
+	if err != nil {
+		if *configPath == "" && errors.Is(err, os.ErrNotExist) {
+			return &config.Config{}
+		}
+		logger.Fatal(err.Error())
+	}
+
+	logger.Info("configuration loaded from %s", path)
+	return cfg
+}
+
+func validateIncomingParams(logger *log.Log, upgradeVersionCmd *flag.FlagSet, gitHost, groupName, projectName *string, credentials git.Auth, upgradePyFile *bool) {
 	if *gitHost == "" {
-		logger.Info(colorRed + "Oops! Git host name must be specified." + colorReset + "[docker run neowaylabs/semantic-release up " + colorYellow + "-git-host gitHostNameHere]" + colorReset)
+		logger.Error("Oops! Git host name must be specified. [docker run neowaylabs/semantic-release up -git-host gitHostNameHere]")
 		os.Exit(1)
 	}
 
 	if *groupName == "" {
-		logger.Info(colorRed + "Oops! Git group name must be specified." + colorReset + " [docker run neowaylabs/semantic-release up -git-host gitHostNameHere " + colorYellow + "-git-group gitGroupNameHere]" + colorReset)
+		logger.Error("Oops! Git group name must be specified. [docker run neowaylabs/semantic-release up -git-host gitHostNameHere -git-group gitGroupNameHere]")
 		os.Exit(1)
 	}
 
 	if *projectName == "" {
-		logger.Info(colorRed + "Oops! Git project name must be specified." + colorReset + " [docker run neowaylabs/semantic-release up -git-host gitHostNameHere -git-group gitGroupNameHere " + colorYellow + "-git-project gitProjectNameHere]" + colorReset)
+		logger.Error("Oops! Git project name must be specified. [docker run neowaylabs/semantic-release up -git-host gitHostNameHere -git-group gitGroupNameHere -git-project gitProjectNameHere]")
 		os.Exit(1)
 	}
 
-	if *username == "" {
-		logger.Info(colorRed + "Oops! Username must be specified." + colorReset + " [docker run neowaylabs/semantic-release up -git-host gitHostNameHere -git-group gitGroupNameHere -git-project gitProjectNameHere " + colorYellow + "-username gitUsername]" + colorReset)
+	// tokens and SSH keys do not require username and password
+	basicAuth, isBasicAuth := credentials.(*git.BasicAuth)
+	if !isBasicAuth {
+		return
+	}
+
+	if basicAuth.Username == "" {
+		logger.Error("Oops! Username must be specified. [docker run neowaylabs/semantic-release up -git-host gitHostNameHere -git-group gitGroupNameHere -git-project gitProjectNameHere -username gitUsername]")
 		os.Exit(1)
 	}
 
-	if *password == "" {
-		logger.Info(colorRed + "Oops! password must be specified." + colorReset + " [docker run neowaylabs/semantic-release up -git-host gitHostNameHere -git-group gitGroupNameHere -git-project gitProjectNameHere -username gitUsername " + colorYellow + "-password gitPassword]" + colorReset)
+	if basicAuth.Password == "" {
+		logger.Error("Oops! password must be specified. [docker run neowaylabs/semantic-release up -git-host gitHostNameHere -git-group gitGroupNameHere -git-project gitProjectNameHere -username gitUsername -password gitPassword]")
 		os.Exit(1)
 	}
 }
 
+func printNextVersion(outputFormat, newVersion, upgradeType string) error {
+	switch outputFormat {
+	case "text":
+		fmt.Printf("%s %s\n", newVersion, upgradeType)
+	case "json":
+		output, err := json.Marshal(map[string]string{"version": newVersion, "bump": upgradeType})
+		if err != nil {
+			return fmt.Errorf("error while marshalling next version due to: %w", err)
+		}
+		fmt.Println(string(output))
+	case "env":
+		fmt.Printf("NEXT_VERSION=%s\nNEXT_VERSION_BUMP=%s\n", newVersion, upgradeType)
+	default:
+		return fmt.Errorf("output format %s is not supported. Expected text, json or env", outputFormat)
+	}
+
+	return nil
+}
+
 func printWelcomeMessage() {
-	fmt.Println(colorYellow + "\nWelcome to the Semantic Release CLI!" + colorReset)
+	fmt.Println(style.Yellow + "\nWelcome to the Semantic Release CLI!" + style.Reset)
 	fmt.Println("\n\tThis CLI allows you to automatically upgrade a git project. \n\t\t* It changes the CHANGELOG.md file.\n\t\t* It Changes setup.py file (if setup-py parameter is set as true).\n\t\t* It also pushes the changes to master, creating and pushing a new corresponding tag.")
 }
 
 func printMainCommands() {
-	fmt.Println(colorYellow + "\n\nHow to use it?" + colorReset)
+	fmt.Println(style.Yellow + "\n\nHow to use it?" + style.Reset)
 	fmt.Println("\nThere are three main commands as follows:")
-	fmt.Println(colorYellow + "\n\t* [docker run neowaylabs/semantic-release help]" + colorReset + ": this command shows you how to properly use the Semantic Release CLI.")
-	fmt.Println(colorYellow + "\n\t* [docker run neowaylabs/semantic-release help-cmt]" + colorReset + ": this command shows you the commit types considered by the Semantic Release CLI.")
-	fmt.Println(colorYellow + "\n\t* [docker run neowaylabs/semantic-release up -git-host gitHostNameHere -group gitGroupNameHere -project gitProjectNameHere -username gitUsername -password gitPassword]" + colorReset + ": this command aims to automatically upgrade the project release version based on current commit subject.")
-	fmt.Println("\nAvailable Parameters for " + colorYellow + "[docker run neowaylabs/semantic-release up]:" + colorReset)
-}
-
-func printCommitTypes() {
-	fmt.Println(colorYellow + "\nTHE AVAILABLE COMMIT TYPES ARE:" + colorReset)
-	fmt.Println(colorYellow + "\n\t*            [build]" + colorReset + ": Changes that affect the build system or external dependencies (example scopes: gulp, broccoli, npm)")
-	fmt.Println(colorYellow + "\t*               [ci]" + colorReset + ": Changes to our CI configuration files and scripts (example scopes: Travis, Circle, BrowserStack, SauceLabs)")
-	fmt.Println(colorYellow + "\t*             [docs]" + colorReset + ": Documentation only changes")
-	fmt.Println(colorYellow + "\t*    [documentation]" + colorReset + ": ||")
-	fmt.Println(colorYellow + "\t*             [feat]" + colorReset + ": A new feature")
-	fmt.Println(colorYellow + "\t*          [feature]" + colorReset + ": ||")
-	fmt.Println(colorYellow + "\t*              [fix]" + colorReset + ": A bug fix")
-	fmt.Println(colorYellow + "\t*             [perf]" + colorReset + ": A code change that improves performance")
-	fmt.Println(colorYellow + "\t*      [performance]" + colorReset + ": ||")
-	fmt.Println(colorYellow + "\t*         [refactor]" + colorReset + ": A code change that neither fixes a bug nor adds a feature")
-	fmt.Println(colorYellow + "\t*            [style]" + colorReset + ": Changes that do not affect the meaning of the code (white-space, formatting, missing semi-colons, etc)")
-	fmt.Println(colorYellow + "\t*             [test]" + colorReset + ": Adding missing tests or correcting existing tests")
-	fmt.Println(colorYellow + "\t*            [chore]" + colorReset + ": No production code change. It won't release a new version.")
-	fmt.Println(colorYellow + "\t*             [skip]" + colorReset + ": Skip versioning")
-	fmt.Println(colorYellow + "\t*               [bc]" + colorReset + ": Changes that will require other changes in dependant applications")
-	fmt.Println(colorYellow + "\t*         [breaking]" + colorReset + ": ||")
-	fmt.Println(colorYellow + "\t*  [breaking change]" + colorReset + ": ||")
+	fmt.Println(style.Yellow + "\n\t* [docker run neowaylabs/semantic-release help]" + style.Reset + ": this command shows you how to properly use the Semantic Release CLI.")
+	fmt.Println(style.Yellow + "\n\t* [docker run neowaylabs/semantic-release help-cmt]" + style.Reset + ": this command shows you the commit types considered by the Semantic Release CLI.")
+	fmt.Println(style.Yellow + "\n\t* [docker run neowaylabs/semantic-release up -git-host gitHostNameHere -group gitGroupNameHere -project gitProjectNameHere -username gitUsername -password gitPassword]" + style.Reset + ": this command aims to automatically upgrade the project release version based on current commit subject.")
+	fmt.Println(style.Yellow + "\n\t* [docker run -v $(pwd):/repo neowaylabs/semantic-release up -repo-path /repo -username gitUsername -password gitPassword]" + style.Reset + ": same as above, but using the repository already checked out instead of cloning it.")
+	fmt.Println(style.Yellow + "\n\t* [docker run -v $(pwd):/repo neowaylabs/semantic-release next-version -repo-path /repo -output json]" + style.Reset + ": this command only prints the version the up command would release and its upgrade type (major, minor, patch or none). It accepts the same parameters as the up command.")
+	fmt.Println("\nAvailable Parameters for " + style.Yellow + "[docker run neowaylabs/semantic-release up]:" + style.Reset)
+}
+
+// commitTypeDescriptions describes the default commit types in the help. Types declared in the configuration file have none.
+var commitTypeDescriptions = map[string]string{
+	"build":           "Changes that affect the build system or external dependencies (example scopes: gulp, broccoli, npm)",
+	"ci":              "Changes to our CI configuration files and scripts (example scopes: Travis, Circle, BrowserStack, SauceLabs)",
+	"docs":            "Documentation only changes",
+	"documentation":   "Documentation only changes",
+	"feat":            "A new feature",
+	"feature":         "A new feature",
+	"fix":             "A bug fix",
+	"perf":            "A code change that improves performance",
+	"performance":     "A code change that improves performance",
+	"refactor":        "A code change that neither fixes a bug nor adds a feature",
+	"style":           "Changes that do not affect the meaning of the code (white-space, formatting, missing semi-colons, etc)",
+	"test":            "Adding missing tests or correcting existing tests",
+	"chore":           "No production code change",
+	"skip":            "Skip versioning",
+	"bc":              "Changes that will require other changes in dependant applications",
+	"breaking":        "Changes that will require other changes in dependant applications",
+	"breaking change": "Changes that will require other changes in dependant applications",
+}
+
+// printCommitTypes prints the commit types accepted, the default ones or the ones of the configuration file, with the upgrade they trigger.
+func printCommitTypes(commitTypeManager *committype.CommitType) {
+	fmt.Println(style.Yellow + "\nTHE AVAILABLE COMMIT TYPES ARE:\n" + style.Reset)
+	for _, group := range []struct {
+		upgrade string
+		types   []string
+	}{
+		{"Releases a major version", commitTypeManager.GetMajorUpgrade()},
+		{"Releases a minor version", commitTypeManager.GetMinorUpgrade()},
+		{"Releases a patch version", commitTypeManager.GetPatchUpgrade()},
+		{"Releases no new version", commitTypeManager.GetSkipVersioning()},
+	} {
+		for _, commitType := range group.types {
+			description := group.upgrade
+			if commitTypeDescriptions[commitType] != "" {
+				description = commitTypeDescriptions[commitType] + ". " + group.upgrade
+			}
+			fmt.Printf(style.Yellow+"\t* %18s"+style.Reset+": %s.\n", "["+commitType+"]", description)
+		}
+	}
 }
 
 func printCommitMessageExample() {
-	fmt.Println(colorYellow + "\nCOMMIT MESSAGE PATTERN" + colorReset)
+	fmt.Println(style.Yellow + "\nCOMMIT MESSAGE PATTERN" + style.Reset)
 	fmt.Println("\nThe commit message must follow the pattern below.")
 	fmt.Println("\n\ttype(optional scope): Commit subject message here.")
-	fmt.Println(colorYellow + "\n\tI.e." + colorReset)
+	fmt.Println(style.Yellow + "\n\tI.e." + style.Reset)
 	fmt.Println("\t\tfeat(config): Added new feature to handle configs.")
 
 	fmt.Println("\n\tNote 1: The (scope) is optional. Semantic-release accepts the following pattern: \"type: Commit subject message here\".")
 	fmt.Println("\n\tNote 2: The maximum number of characters is 150. If the commit subject exceeds it, it will be cut, keeping only the first 150 characters.")
 }
 
-func newSemantic(logger *log.Log, upgradeVersionCmd *flag.FlagSet, gitHost, groupName, projectName, username, password *string, upgradePyFile *bool, branchName *string) *semantic.Semantic {
+// newSSHAuth returns the SSH credentials when a private key or the ssh-agent is informed, otherwise nil.
+func newSSHAuth(sshUser, sshKey, sshKeyPassphrase, sshKnownHosts *string, sshAgent *bool) *git.SSHAuth {
+	if *sshKey == "" && !*sshAgent {
+		return nil
+	}
 
-	validateIncomingParams(logger, upgradeVersionCmd, gitHost, groupName, projectName, username, password, upgradePyFile)
+	return &git.SSHAuth{
+		User:           *sshUser,
+		PrivateKeyPath: *sshKey,
+		Passphrase:     *sshKeyPassphrase,
+		UseAgent:       *sshAgent,
+		KnownHostsPath: *sshKnownHosts,
+	}
+}
 
-	timer := time.New(logger)
-	repositoryRootPath := fmt.Sprintf("%s/%s", homePath, *projectName)
+// newCredentials returns the credentials informed, in order of precedence: SSH, GitLab CI job token, access token or
+// token file, netrc, git credential helpers and finally username and password.
+func newCredentials(gitHost, username, password, token, tokenFile, jobToken *string, bearer, netrc, credentialHelper *bool, sshAuth *git.SSHAuth) (git.Auth, error) {
+	// netrc and credential helpers look the credentials up by host, which is neither informed nor inferred from the remote
+	if (*netrc || *credentialHelper) && *gitHost == "" {
+		return nil, errors.New("git host must be specified to look up its credentials")
+	}
+
+	switch {
+	case sshAuth != nil:
+		return sshAuth, nil
+	case *jobToken != "":
+		return git.NewJobTokenAuth(*jobToken), nil
+	case *token != "":
+		return &git.TokenAuth{Username: *username, Token: *token, Bearer: *bearer}, nil
+	case *tokenFile != "":
+		value, err := git.TokenFromFile(*tokenFile)
+		if err != nil {
+			return nil, err
+		}
+		return &git.TokenAuth{Username: *username, Token: value, Bearer: *bearer}, nil
+	case *netrc:
+		return git.NetrcAuth("", *gitHost)
+	case *credentialHelper:
+		return git.CredentialHelperAuth(*gitHost)
+	}
+
+	return &git.BasicAuth{Username: *username, Password: *password}, nil
+}
+
+// registerSecrets masks the secrets of the credentials in the logs, including the ones read from files and helpers.
+func registerSecrets(logger *log.Log, credentials git.Auth) {
+	switch auth := credentials.(type) {
+	case *git.BasicAuth:
+		logger.AddSecrets(auth.Password)
+	case *git.TokenAuth:
+		logger.AddSecrets(auth.Token)
+	case *git.SSHAuth:
+		logger.AddSecrets(auth.Passphrase)
+	}
+}
 
-	url := fmt.Sprintf("https://%s:%s@%s/%s/%s.git", *username, *password, *gitHost, *groupName, *projectName)
-	repoVersionControl, err := git.New(logger, timer.PrintElapsedTime, url, *username, *password, repositoryRootPath, *branchName)
+// resolveReleaseToken returns the token used to publish releases, which defaults to the token or password of the git credentials.
+// It also returns whether it is a GitLab CI job token.
+func resolveReleaseToken(credentials git.Auth, token string) (string, bool) {
+	if token != "" {
+		return token, false
+	}
+
+	switch auth := credentials.(type) {
+	case *git.TokenAuth:
+		return auth.Token, auth.IsJobToken()
+	case *git.BasicAuth:
+		return auth.Password, false
+	}
+
+	return "", false
+}
+
+// newReleasePublisher returns the client publishing releases in the provider.
+func newReleasePublisher(logger *log.Log, tlsConfig *git.TLSConfig, credentials git.Auth, releaseConfig config.Release, provider, apiURL, token, gitHost, groupName, projectName string) (semantic.ReleasePublisher, error) {
+	httpClient, err := tlsConfig.HTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	token, isJobToken := resolveReleaseToken(credentials, token)
+
+	switch provider {
+	case constants.ProviderGitLab:
+		if apiURL == "" {
+			apiURL = release.DefaultGitLabAPIURL(gitHost)
+		}
+
+		tokenHeader := release.GitLabPrivateTokenHeader
+		if isJobToken {
+			tokenHeader = release.GitLabJobTokenHeader
+		}
+
+		gitLab, err := release.NewGitLab(logger, httpClient, apiURL, groupName+"/"+projectName, tokenHeader, token)
+		if err != nil {
+			return nil, fmt.Errorf("error while creating gitlab release client due to: %w", err)
+		}
+
+		gitLab.SetMilestones(releaseConfig.Milestones)
+		assets := make([]release.AssetLink, 0, len(releaseConfig.Assets))
+		for _, asset := range releaseConfig.Assets {
+			assets = append(assets, release.AssetLink{Name: asset.Name, URL: asset.URL, Type: asset.Type})
+		}
+		gitLab.SetAssetLinks(assets)
+
+		return gitLab, nil
+	case constants.ProviderGitHub:
+		if apiURL == "" {
+			apiURL = release.DefaultGitHubAPIURL(gitHost)
+		}
+
+		gitHub, err := release.NewGitHub(logger, httpClient, apiURL, groupName, projectName, token)
+		if err != nil {
+			return nil, fmt.Errorf("error while creating github release client due to: %w", err)
+		}
+
+		gitHub.SetDraft(releaseConfig.Draft)
+		return gitHub, nil
+	}
+
+	return nil, fmt.Errorf("release provider %s is not supported. Expected gitlab or github", provider)
+}
+
+// fillFromCI sets git host, group and project from the CI platform predefined variables when they were not informed.
+// The branch is only inferred to lint its commits, because in merge request pipelines it is the source branch, which up must not release.
+func fillFromCI(logger *log.Log, gitHost, groupName, projectName, branchName *string, commitLint bool) {
+	environment, found := ci.Detect(os.Getenv)
+	if !found {
+		return
+	}
+
+	logger.Info("%s CI detected", environment.Provider)
+	environment.Fill(gitHost, groupName, projectName)
+
+	if commitLint && *branchName == "" && environment.Branch != "" {
+		logger.Info("branch %s inferred from the CI variables", environment.Branch)
+		*branchName = environment.Branch
+	}
+}
+
+// fillFromRemote sets git host, group and project from the origin remote when they were not informed.
+func fillFromRemote(logger *log.Log, repoVersionControl *git.GitVersioning, gitHost, groupName, projectName *string) {
+	if *gitHost != "" && *groupName != "" && *projectName != "" {
+		return
+	}
+
+	remoteURL, err := repoVersionControl.GetRemoteURL()
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+
+	remote, err := git.ParseRemoteURL(remoteURL)
 	if err != nil {
 		logger.Fatal(err.Error())
 	}
 
-	commitTypeManager := committype.New(logger)
-	commitMessageManager := commitmessage.New(logger, commitTypeManager)
+	if *gitHost == "" {
+		*gitHost = remote.Host
+	}
+
+	if *groupName == "" {
+		*groupName = remote.Group
+	}
+
+	if *projectName == "" {
+		*projectName = remote.Project
+	}
+}
+
+// newLinkTemplates returns the changelog links of the configured provider, of the release provider or of the one detected from the git host.
+func newLinkTemplates(links config.Links, releaseProvider, gitHost string) (files.LinkTemplates, error) {
+	provider := links.Provider
+	if provider == "" {
+		provider = releaseProvider
+	}
+	if provider == "" {
+		provider = files.DetectProvider(gitHost)
+	}
+
+	templates, err := files.GetProviderLinkTemplates(provider)
+	if err != nil {
+		return files.LinkTemplates{}, err
+	}
+
+	if links.Commit != "" {
+		templates.Commit = links.Commit
+	}
+	if links.Compare != "" {
+		templates.Compare = links.Compare
+	}
+
+	return templates, nil
+}
+
+func newSemantic(logger *log.Log, upgradeVersionCmd *flag.FlagSet, gitHost, groupName, projectName *string, resolveCredentials func() (git.Auth, error), tlsConfig *git.TLSConfig, upgradePyFile, upgradePyprojectFile *bool, branchName, repoPath *string, dryRun *bool, configPath, changelogPath, tagFormat *string, legacyTags *bool, releaseProvider, releaseAPIURL, releaseToken *string, publishRelease bool) (*semantic.Semantic, *committype.CommitType) {
+	timer := time.New(logger)
+
+	var repositoryRootPath string
+	var repoVersionControl *git.GitVersioning
+	var credentials git.Auth
+	var err error
+
+	if *repoPath != "" {
+		repositoryRootPath = *repoPath
+		repoVersionControl, err = git.NewLocalWithAuth(logger.WithComponent("git"), timer.PrintElapsedTime, nil, repositoryRootPath, *branchName)
+		if err != nil {
+			logger.Fatal(err.Error())
+		}
+
+		fillFromRemote(logger, repoVersionControl, gitHost, groupName, projectName)
+
+		credentials, err = resolveCredentials()
+		if err != nil {
+			logger.Fatal(err.Error())
+		}
+		repoVersionControl.SetCredentials(credentials)
+	} else {
+		credentials, err = resolveCredentials()
+		if err != nil {
+			logger.Fatal(err.Error())
+		}
+
+		validateIncomingParams(logger, upgradeVersionCmd, gitHost, groupName, projectName, credentials, upgradePyFile)
+
+		repositoryRootPath = fmt.Sprintf("%s/%s", homePath, *projectName)
+		// credentials go through the transport auth method only, so they are never written to .git/config nor logged
+		url := fmt.Sprintf("https://%s/%s/%s.git", *gitHost, *groupName, *projectName)
+		if sshAuth, isSSH := credentials.(*git.SSHAuth); isSSH {
+			url = fmt.Sprintf("ssh://%s@%s/%s/%s.git", sshAuth.User, *gitHost, *groupName, *projectName)
+		}
+
+		repoVersionControl, err = git.NewWithAuth(logger.WithComponent("git"), timer.PrintElapsedTime, url, credentials, repositoryRootPath, *branchName)
+		if err != nil {
+			logger.Fatal(err.Error())
+		}
+	}
+
+	cfg := loadConfig(logger, configPath, repositoryRootPath)
+
+	if *tagFormat == "" {
+		*tagFormat = cfg.TagFormat
+	}
+
+	// legacy tags already follow the default tag format, so there is nothing to migrate without a custom one
+	if *tagFormat != "" {
+		if err := repoVersionControl.SetTagFormat(*tagFormat, *legacyTags || cfg.LegacyTags); err != nil {
+			logger.Fatal(err.Error())
+		}
+	}
+
+	// pre-releases are computed from the last stable release, so pre-release tags never drive the current version
+	preReleaseChannel := ""
+	if len(cfg.PreReleaseBranches) > 0 {
+		if err := repoVersionControl.SetIgnorePreReleaseTags(true); err != nil {
+			logger.Fatal(err.Error())
+		}
+
+		if channel, found := cfg.GetPreReleaseChannel(repoVersionControl.GetBranchName()); found {
+			logger.Info("branch %s publishes pre-releases of channel %s", repoVersionControl.GetBranchName(), channel)
+			preReleaseChannel = channel
+		}
+	}
+
+	maintenanceRange, isMaintenanceBranch := semver.ParseMaintenanceRange(repoVersionControl.GetBranchName())
+	if isMaintenanceBranch {
+		logger.Info("branch %s is a maintenance branch. Releases must stay within the range %s", repoVersionControl.GetBranchName(), maintenanceRange.String())
+		if err := repoVersionControl.SetMaintenanceRange(maintenanceRange); err != nil {
+			logger.Fatal(err.Error())
+		}
+	}
+
+	commitTypeManager := newCommitTypeManager(logger, cfg)
+
+	commitMessageManager := commitmessage.New(logger.WithComponent("commit-message"), commitTypeManager)
+
+	filesVersionControl := files.New(logger.WithComponent("files"), timer.PrintElapsedTime, *gitHost, repositoryRootPath, *groupName, *projectName, commitMessageManager)
+	filesVersionControl.SetDryRun(*dryRun)
+
+	versionControl := v.NewVersionControl(logger.WithComponent("version"), timer.PrintElapsedTime, commitTypeManager)
+
+	semanticService := semantic.New(logger.WithComponent("semantic"), repositoryRootPath, addFilesToUpgradeList(upgradePyFile, upgradePyprojectFile, repositoryRootPath, cfg.VersionFiles), repoVersionControl, filesVersionControl, versionControl, commitMessageManager, commitTypeManager)
+	semanticService.SetDryRun(*dryRun)
+	semanticService.SetPreReleaseChannel(preReleaseChannel)
+	if isMaintenanceBranch {
+		semanticService.SetMaintenanceRange(maintenanceRange)
+	}
+
+	if *releaseProvider == "" {
+		*releaseProvider = cfg.Release.Provider
+	}
 
-	filesVersionControl := files.New(logger, timer.PrintElapsedTime, *gitHost, repositoryRootPath, *groupName, *projectName, commitMessageManager)
+	if *releaseAPIURL == "" {
+		*releaseAPIURL = cfg.Release.APIURL
+	}
 
-	versionControl := v.NewVersionControl(logger, timer.PrintElapsedTime, commitTypeManager)
+	linkTemplates, err := newLinkTemplates(cfg.Links, *releaseProvider, *gitHost)
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+	filesVersionControl.SetLinkTemplates(linkTemplates)
+
+	if publishRelease && *releaseProvider != "" {
+		releasePublisher, err := newReleasePublisher(logger.WithComponent("release"), tlsConfig, credentials, cfg.Release, *releaseProvider, *releaseAPIURL, *releaseToken, *gitHost, *groupName, *projectName)
+		if err != nil {
+			logger.Fatal(err.Error())
+		}
+		semanticService.SetReleasePublisher(releasePublisher)
+	}
+
+	if *changelogPath != "" {
+		semanticService.SetChangelogPath(resolvePath(repositoryRootPath, *changelogPath))
+	} else if cfg.Changelog != "" {
+		semanticService.SetChangelogPath(resolvePath(repositoryRootPath, cfg.Changelog))
+	}
 
-	return semantic.New(logger, repositoryRootPath, addFilesToUpgradeList(upgradePyFile, repositoryRootPath), repoVersionControl, filesVersionControl, versionControl, commitMessageManager, commitTypeManager)
+	return semanticService, commitTypeManager
 }
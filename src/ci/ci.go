@@ -0,0 +1,137 @@
+package ci
+
+import (
+	"flag"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	gitlabProvider = "gitlab"
+	githubProvider = "github"
+)
+
+// Getenv returns the value of an environment variable. os.Getenv satisfies it.
+type Getenv func(key string) string
+
+// Environment holds the project coordinates inferred from a CI platform.
+type Environment struct {
+	Provider string
+	Host     string
+	Group    string
+	Project  string
+	Branch   string
+}
+
+func firstNotEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// detectGitLab reads the GitLab CI predefined variables.
+// See https://docs.gitlab.com/ee/ci/variables/predefined_variables.html
+func detectGitLab(getenv Getenv) Environment {
+	return Environment{
+		Provider: gitlabProvider,
+		Host:     getenv("CI_SERVER_HOST"),
+		Group:    getenv("CI_PROJECT_NAMESPACE"),
+		Project:  getenv("CI_PROJECT_NAME"),
+		// CI_COMMIT_REF_NAME is the tag name in tag pipelines, so only the branch variables are read
+		Branch: firstNotEmpty(getenv("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME"), getenv("CI_COMMIT_BRANCH")),
+	}
+}
+
+// detectGitHub reads the GitHub Actions default variables.
+// See https://docs.github.com/en/actions/learn-github-actions/variables#default-environment-variables
+func detectGitHub(getenv Getenv) Environment {
+	environment := Environment{
+		Provider: githubProvider,
+		Branch:   getenv("GITHUB_HEAD_REF"),
+	}
+
+	// GITHUB_REF_NAME is the tag name in workflows triggered by tags
+	if environment.Branch == "" && getenv("GITHUB_REF_TYPE") == "branch" {
+		environment.Branch = getenv("GITHUB_REF_NAME")
+	}
+
+	if serverURL, err := url.Parse(getenv("GITHUB_SERVER_URL")); err == nil {
+		environment.Host = serverURL.Host
+	}
+
+	repository := getenv("GITHUB_REPOSITORY")
+	if index := strings.LastIndex(repository, "/"); index != -1 {
+		environment.Group = repository[:index]
+		environment.Project = repository[index+1:]
+	}
+
+	return environment
+}
+
+// Detect infers the project coordinates from the CI platform the process runs in.
+// It returns false when no supported platform (GitLab CI or GitHub Actions) is found.
+func Detect(getenv Getenv) (Environment, bool) {
+	if getenv("GITLAB_CI") == "true" {
+		return detectGitLab(getenv), true
+	}
+
+	if getenv("GITHUB_ACTIONS") == "true" {
+		return detectGitHub(getenv), true
+	}
+
+	return Environment{}, false
+}
+
+// Fill sets every value not informed to the one inferred from the CI platform.
+// The branch is not filled, since in merge request pipelines it is the source branch, which must not be released.
+func (e Environment) Fill(host, group, project *string) {
+	for _, param := range []struct {
+		value    *string
+		inferred string
+	}{
+		{host, e.Host},
+		{group, e.Group},
+		{project, e.Project},
+	} {
+		if *param.value == "" {
+			*param.value = param.inferred
+		}
+	}
+}
+
+// EnvironmentVariableName returns the variable a flag falls back to. I.e.: git-host returns SEMANTIC_RELEASE_GIT_HOST
+func EnvironmentVariableName(flagName string) string {
+	return "SEMANTIC_RELEASE_" + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+// SetFlagsFromEnvironment sets every flag not informed in the command line from its environment variable.
+// See EnvironmentVariableName.
+func SetFlagsFromEnvironment(flagSet *flag.FlagSet, getenv Getenv) error {
+	informed := make(map[string]bool)
+	flagSet.Visit(func(f *flag.Flag) {
+		informed[f.Name] = true
+	})
+
+	var err error
+	flagSet.VisitAll(func(f *flag.Flag) {
+		if err != nil || informed[f.Name] {
+			return
+		}
+
+		variableName := EnvironmentVariableName(f.Name)
+		value := getenv(variableName)
+		if value == "" {
+			return
+		}
+
+		if setErr := flagSet.Set(f.Name, value); setErr != nil {
+			err = fmt.Errorf("invalid value for %s: %w", variableName, setErr)
+		}
+	})
+
+	return err
+}
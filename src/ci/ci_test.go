@@ -0,0 +1,131 @@
+//go:build unit
+// +build unit
+
+package ci_test
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/NeowayLabs/semantic-release/src/ci"
+	"github.com/NeowayLabs/semantic-release/src/tests"
+)
+
+func getenvMock(variables map[string]string) ci.Getenv {
+	return func(key string) string {
+		return variables[key]
+	}
+}
+
+func TestDetectNotFound(t *testing.T) {
+	_, found := ci.Detect(getenvMock(map[string]string{"CI_SERVER_HOST": "gitlab.com"}))
+	tests.AssertFalse(t, found)
+}
+
+func TestDetectGitLabSuccess(t *testing.T) {
+	environment, found := ci.Detect(getenvMock(map[string]string{
+		"GITLAB_CI":            "true",
+		"CI_SERVER_HOST":       "gitlab.com",
+		"CI_PROJECT_NAMESPACE": "dataplatform/subgroup",
+		"CI_PROJECT_NAME":      "integration-tests",
+		"CI_COMMIT_BRANCH":     "main",
+		"CI_COMMIT_REF_NAME":   "main",
+	}))
+	tests.AssertTrue(t, found)
+	tests.AssertEqualValues(t, ci.Environment{Provider: "gitlab", Host: "gitlab.com", Group: "dataplatform/subgroup", Project: "integration-tests", Branch: "main"}, environment)
+}
+
+func TestDetectGitLabMergeRequestBranchSuccess(t *testing.T) {
+	environment, found := ci.Detect(getenvMock(map[string]string{
+		"GITLAB_CI":                           "true",
+		"CI_COMMIT_REF_NAME":                  "refs/merge-requests/1/head",
+		"CI_MERGE_REQUEST_SOURCE_BRANCH_NAME": "feature-branch",
+	}))
+	tests.AssertTrue(t, found)
+	tests.AssertEqualValues(t, "feature-branch", environment.Branch)
+}
+
+func TestDetectGitLabTagPipelineWithoutBranch(t *testing.T) {
+	environment, found := ci.Detect(getenvMock(map[string]string{
+		"GITLAB_CI":          "true",
+		"CI_COMMIT_REF_NAME": "v1.0.0",
+		"CI_COMMIT_TAG":      "v1.0.0",
+	}))
+	tests.AssertTrue(t, found)
+	tests.AssertEqualValues(t, "", environment.Branch)
+}
+
+func TestDetectGitHubSuccess(t *testing.T) {
+	environment, found := ci.Detect(getenvMock(map[string]string{
+		"GITHUB_ACTIONS":    "true",
+		"GITHUB_SERVER_URL": "https://github.example.com",
+		"GITHUB_REPOSITORY": "NeowayLabs/semantic-release",
+		"GITHUB_REF_TYPE":   "branch",
+		"GITHUB_REF_NAME":   "main",
+	}))
+	tests.AssertTrue(t, found)
+	tests.AssertEqualValues(t, ci.Environment{Provider: "github", Host: "github.example.com", Group: "NeowayLabs", Project: "semantic-release", Branch: "main"}, environment)
+}
+
+func TestDetectGitHubPullRequestBranchSuccess(t *testing.T) {
+	environment, found := ci.Detect(getenvMock(map[string]string{
+		"GITHUB_ACTIONS":  "true",
+		"GITHUB_REF_NAME": "1/merge",
+		"GITHUB_HEAD_REF": "feature-branch",
+	}))
+	tests.AssertTrue(t, found)
+	tests.AssertEqualValues(t, "feature-branch", environment.Branch)
+}
+
+func TestDetectGitHubTagWorkflowWithoutBranch(t *testing.T) {
+	environment, found := ci.Detect(getenvMock(map[string]string{
+		"GITHUB_ACTIONS":  "true",
+		"GITHUB_REF_TYPE": "tag",
+		"GITHUB_REF_NAME": "v1.0.0",
+	}))
+	tests.AssertTrue(t, found)
+	tests.AssertEqualValues(t, "", environment.Branch)
+}
+
+func TestEnvironmentFillNotInformed(t *testing.T) {
+	environment := ci.Environment{Host: "gitlab.com", Group: "dataplatform", Project: "integration-tests", Branch: "develop"}
+	host, group, project := "github.com", "", ""
+
+	environment.Fill(&host, &group, &project)
+	tests.AssertEqualValues(t, "github.com", host)
+	tests.AssertEqualValues(t, "dataplatform", group)
+	tests.AssertEqualValues(t, "integration-tests", project)
+}
+
+func TestEnvironmentVariableName(t *testing.T) {
+	tests.AssertEqualValues(t, "SEMANTIC_RELEASE_GIT_HOST", ci.EnvironmentVariableName("git-host"))
+	tests.AssertEqualValues(t, "SEMANTIC_RELEASE_USERNAME", ci.EnvironmentVariableName("username"))
+}
+
+func TestSetFlagsFromEnvironmentSuccess(t *testing.T) {
+	flagSet := flag.NewFlagSet("up", flag.ContinueOnError)
+	gitHost := flagSet.String("git-host", "", "")
+	groupName := flagSet.String("git-group", "", "")
+	setupPy := flagSet.Bool("setup-py", false, "")
+	err := flagSet.Parse([]string{"-git-group", "from-flag"})
+	tests.AssertNoError(t, err)
+
+	err = ci.SetFlagsFromEnvironment(flagSet, getenvMock(map[string]string{
+		"SEMANTIC_RELEASE_GIT_HOST":  "gitlab.com",
+		"SEMANTIC_RELEASE_GIT_GROUP": "from-env",
+		"SEMANTIC_RELEASE_SETUP_PY":  "true",
+	}))
+	tests.AssertNoError(t, err)
+	tests.AssertEqualValues(t, "gitlab.com", *gitHost)
+	tests.AssertEqualValues(t, "from-flag", *groupName)
+	tests.AssertTrue(t, *setupPy)
+}
+
+func TestSetFlagsFromEnvironmentInvalidValueError(t *testing.T) {
+	flagSet := flag.NewFlagSet("up", flag.ContinueOnError)
+	flagSet.Bool("setup-py", false, "")
+
+	err := ci.SetFlagsFromEnvironment(flagSet, getenvMock(map[string]string{"SEMANTIC_RELEASE_SETUP_PY": "maybe"}))
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, "invalid value for SEMANTIC_RELEASE_SETUP_PY: parse error", err.Error())
+}
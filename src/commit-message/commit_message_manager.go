@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+
+	committype "github.com/NeowayLabs/semantic-release/src/commit-type"
 )
 
 type Logger interface {
@@ -21,6 +23,7 @@ type CommitType interface {
 	GetSkipVersioning() []string
 	GetCommitChangeType(commitMessage string) (string, error)
 	IndexNotFound(index int) bool
+	ParseCommit(commitMessage string) (*committype.ParsedCommit, error)
 }
 
 type CommitMessage struct {
@@ -46,29 +49,13 @@ func (f *CommitMessage) upperFirstLetterOfSentence(text string) string {
 //	string: Returns a commit message with limmited number of characters.
 //	err: Error whenever unexpected issues happen.
 func (f *CommitMessage) PrettifyCommitMessage(commitMessage string) (string, error) {
-	splitedMessage := strings.Split(commitMessage, "\n")
-
-	message := ""
-	for _, row := range splitedMessage {
-		index := strings.Index(row, ":")
-
-		if f.commitType.IndexNotFound(index) || row == "" {
-			continue
-		}
-
-		commitTypeScope := strings.ToLower(row[:index])
-
-		for _, changeType := range f.commitType.GetAll() {
-			if strings.Contains(commitTypeScope, changeType) {
-				message = strings.TrimSpace(strings.Replace(row[index:], ":", "", 1))
-			}
-		}
-	}
-
-	if message == "" {
+	parsedCommit, err := f.commitType.ParseCommit(commitMessage)
+	if err != nil || parsedCommit.Subject == "" {
 		return "", errors.New("commit message is empty")
 	}
 
+	message := parsedCommit.Subject
+
 	if f.isMessageLongerThanLimit(message) {
 		message = fmt.Sprintf("%s...", message[:150])
 	}
@@ -122,20 +109,29 @@ func (f *CommitMessage) IsValidMessage(message string) bool {
 		return false
 	}
 
-	if message == "" || message[index:] == ":" {
+	parsedCommit, err := f.commitType.ParseCommit(message)
+	if err != nil {
+		f.log.Error(err.Error())
+		return false
+	}
+
+	if parsedCommit.Subject == "" {
 		f.log.Error("commit message cannot be empty")
 		return false
 	}
 
-	_, err := f.commitType.GetCommitChangeType(message)
+	return true
+}
+
+// IsBreakingChange returns true when the commit message declares a breaking change.
+// I.e.: feat!: Commit subject here. or a BREAKING CHANGE: footer
+func (f *CommitMessage) IsBreakingChange(commitMessage string) bool {
+	parsedCommit, err := f.commitType.ParseCommit(commitMessage)
 	if err != nil {
-		if err.Error() == "change type not found" {
-			f.log.Error("change type not found")
-		}
 		return false
 	}
 
-	return true
+	return parsedCommit.Breaking
 }
 
 func New(log Logger, commitType CommitType) *CommitMessage {
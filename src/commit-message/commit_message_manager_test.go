@@ -125,3 +125,19 @@ func TestIsValidMessageMergeMasterBranchSuccess(t *testing.T) {
 	actual = f.commitMessageManager.IsValidMessage(message)
 	tests.AssertTrue(t, actual)
 }
+
+func TestPrettifyCommitMessageBreakingChangeSuccess(t *testing.T) {
+	f := setup(t)
+	message := "feat(scope)!: this is a breaking change.\n\nBREAKING CHANGE: the config file format changed"
+	prettyMessage, err := f.commitMessageManager.PrettifyCommitMessage(message)
+	tests.AssertNoError(t, err)
+	tests.AssertEqualValues(t, "This is a breaking change.", prettyMessage)
+}
+
+func TestIsBreakingChange(t *testing.T) {
+	f := setup(t)
+	tests.AssertTrue(t, f.commitMessageManager.IsBreakingChange("feat!: This is a message"))
+	tests.AssertTrue(t, f.commitMessageManager.IsBreakingChange("fix: This is a message\n\nBREAKING CHANGE: something changed"))
+	tests.AssertFalse(t, f.commitMessageManager.IsBreakingChange("feat: This is a message"))
+	tests.AssertFalse(t, f.commitMessageManager.IsBreakingChange("wrong type: This is a message"))
+}
@@ -1,8 +1,6 @@
 package committype
 
 import (
-	"errors"
-	"regexp"
 	"strings"
 )
 
@@ -11,36 +9,46 @@ type Logger interface {
 }
 
 type CommitType struct {
-	log Logger
+	log                 Logger
+	allTypes            []string
+	majorUpgradeTypes   []string
+	minorUpgradeTypes   []string
+	patchUpgradeTypes   []string
+	skipVersioningTypes []string
 }
 
 func (c *CommitType) GetAll() []string {
-	return []string{"build", "ci", "docs", "fix", "feat", "feature", "feature", "perf", "performance", "refactor", "style", "test", "bc", "breaking", "breaking change", "chore", "skip"}
+	return c.allTypes
 }
 
 func (c *CommitType) GetMajorUpgrade() []string {
-	return []string{"bc", "breaking", "breaking change"}
+	return c.majorUpgradeTypes
 }
 
 func (c *CommitType) GetMinorUpgrade() []string {
-	return []string{"feat", "feature"}
+	return c.minorUpgradeTypes
 }
 
 func (c *CommitType) GetPatchUpgrade() []string {
-	return []string{"build", "ci", "docs", "documentation", "fix", "perf", "performance", "refactor", "style", "test"}
+	return c.patchUpgradeTypes
 }
 
 func (c *CommitType) GetSkipVersioning() []string {
-	return []string{"skip", "chore"}
+	return c.skipVersioningTypes
 }
 
-func (c *CommitType) isValidCommitType(commitTypeScope string) bool {
-	for _, changeType := range c.GetAll() {
-		if strings.Contains(commitTypeScope, changeType) {
-			return true
-		}
+// Configure replaces the default commit types with the given ones, grouped by the upgrade they trigger.
+// Types are matched in the order they are declared, from major to skip.
+func (c *CommitType) Configure(major, minor, patch, skip []string) {
+	c.majorUpgradeTypes = major
+	c.minorUpgradeTypes = minor
+	c.patchUpgradeTypes = patch
+	c.skipVersioningTypes = skip
+
+	c.allTypes = nil
+	for _, types := range [][]string{major, minor, patch, skip} {
+		c.allTypes = append(c.allTypes, types...)
 	}
-	return false
 }
 
 // GetScope get the commit scope from Message
@@ -51,28 +59,12 @@ func (c *CommitType) isValidCommitType(commitTypeScope string) bool {
 // Output: any
 func (c *CommitType) GetScope(commitMessage string) string {
 	c.log.Info("getting commit scope from message %s", commitMessage)
-	splitedMessage := strings.Split(commitMessage, "\n")
-	re := regexp.MustCompile(`\((.*?)\)`)
-
-	for _, row := range splitedMessage {
-		index := strings.Index(row, ":")
-
-		if c.IndexNotFound(index) || row == " " {
-			continue
-		}
-		commitTypeScope := strings.ToLower(row[:index])
-
-		if c.isValidCommitType(commitTypeScope) {
-			found := re.FindAllString(row, -1)
-			for _, element := range found {
-				element = strings.Trim(element, "(")
-				element = strings.Trim(element, ")")
-				return element
-			}
-		}
+	parsed, err := c.ParseCommit(commitMessage)
+	if err != nil || len(parsed.Scopes) == 0 {
+		return "default"
 	}
 
-	return "default"
+	return strings.Join(parsed.Scopes, ",")
 }
 
 func (c *CommitType) IndexNotFound(index int) bool {
@@ -87,29 +79,21 @@ func (c *CommitType) IndexNotFound(index int) bool {
 // Output: fix
 func (c *CommitType) GetCommitChangeType(commitMessage string) (string, error) {
 	c.log.Info("getting commit type from message %s", commitMessage)
-	splitedMessage := strings.Split(commitMessage, "\n")
-
-	for _, row := range splitedMessage {
-		index := strings.Index(row, ":")
-
-		if c.IndexNotFound(index) || row == "" {
-			continue
-		}
-
-		commitTypeScope := strings.ToLower(row[:index])
-
-		for _, changeType := range c.GetAll() {
-			if strings.Contains(commitTypeScope, changeType) {
-				return changeType, nil
-			}
-		}
+	parsed, err := c.ParseCommit(commitMessage)
+	if err != nil {
+		return "", err
 	}
 
-	return "", errors.New("change type not found")
+	return parsed.Type, nil
 }
 
 func New(log Logger) *CommitType {
 	return &CommitType{
-		log: log,
+		log:                 log,
+		allTypes:            []string{"build", "ci", "docs", "fix", "feat", "feature", "feature", "perf", "performance", "refactor", "style", "test", "bc", "breaking", "breaking change", "chore", "skip"},
+		majorUpgradeTypes:   []string{"bc", "breaking", "breaking change"},
+		minorUpgradeTypes:   []string{"feat", "feature"},
+		patchUpgradeTypes:   []string{"build", "ci", "docs", "documentation", "fix", "perf", "performance", "refactor", "style", "test"},
+		skipVersioningTypes: []string{"skip", "chore"},
 	}
 }
@@ -102,3 +102,83 @@ func TestGetCommitChangeTypeNewLinesSuccess(t *testing.T) {
 	tests.AssertNoError(t, err)
 	tests.AssertEqualValues(t, expected, actualType)
 }
+
+func TestConfigureSuccess(t *testing.T) {
+	f := setup(t)
+	f.commitType.Configure([]string{"breaking"}, []string{"feat"}, []string{"fix", "perf"}, []string{"wip"})
+
+	tests.AssertDeepEqualValues(t, []string{"breaking", "feat", "fix", "perf", "wip"}, f.commitType.GetAll())
+	tests.AssertDeepEqualValues(t, []string{"breaking"}, f.commitType.GetMajorUpgrade())
+	tests.AssertDeepEqualValues(t, []string{"feat"}, f.commitType.GetMinorUpgrade())
+	tests.AssertDeepEqualValues(t, []string{"fix", "perf"}, f.commitType.GetPatchUpgrade())
+	tests.AssertDeepEqualValues(t, []string{"wip"}, f.commitType.GetSkipVersioning())
+
+	actualType, err := f.commitType.GetCommitChangeType("perf(scope): This is a sample message")
+	tests.AssertNoError(t, err)
+	tests.AssertEqualValues(t, "perf", actualType)
+
+	_, err = f.commitType.GetCommitChangeType("docs(scope): This is a sample message")
+	tests.AssertError(t, err)
+}
+
+func TestParseCommitSuccess(t *testing.T) {
+	f := setup(t)
+	message := "feat(api, cli)!: This is a sample message\n\nThis is the body.\nIt has two lines.\n\nRefs: #123\nBREAKING CHANGE: the response format changed\nand it spans two lines."
+	actual, err := f.commitType.ParseCommit(message)
+	tests.AssertNoError(t, err)
+
+	expected := &committype.ParsedCommit{
+		Type:     "feat",
+		Scopes:   []string{"api", "cli"},
+		Breaking: true,
+		Subject:  "This is a sample message",
+		Body:     "This is the body.\nIt has two lines.",
+		Footers: []committype.Footer{
+			{Token: "Refs", Value: "#123"},
+			{Token: "BREAKING CHANGE", Value: "the response format changed\nand it spans two lines."},
+		},
+	}
+	tests.AssertDeepEqualValues(t, expected, actual)
+}
+
+func TestParseCommitBreakingChangeFooterSuccess(t *testing.T) {
+	f := setup(t)
+	for _, message := range []string{
+		"fix: This is a sample message\n\nBREAKING CHANGE: the config file format changed",
+		"fix: This is a sample message\n\nBREAKING-CHANGE: the config file format changed",
+		"fix(scope)!: This is a sample message",
+	} {
+		actual, err := f.commitType.ParseCommit(message)
+		tests.AssertNoError(t, err)
+		tests.AssertEqualValues(t, "fix", actual.Type)
+		tests.AssertTrue(t, actual.Breaking)
+	}
+
+	actual, err := f.commitType.ParseCommit("fix: This is a sample message\n\nThe breaking change word in the body is not a footer.")
+	tests.AssertNoError(t, err)
+	tests.AssertFalse(t, actual.Breaking)
+	tests.AssertEqualValues(t, 0, len(actual.Footers))
+}
+
+func TestParseCommitMergeRequestSuccess(t *testing.T) {
+	f := setup(t)
+	message := "Merge branch 'sample-branch' into 'master'\n\nfeat(scope): This is a message with new lines.\n\nSee merge request gitgroup/semantic-tests!1"
+	actual, err := f.commitType.ParseCommit(message)
+	tests.AssertNoError(t, err)
+	tests.AssertEqualValues(t, "feat", actual.Type)
+	tests.AssertDeepEqualValues(t, []string{"scope"}, actual.Scopes)
+	tests.AssertEqualValues(t, "This is a message with new lines.", actual.Subject)
+	tests.AssertEqualValues(t, "See merge request gitgroup/semantic-tests!1", actual.Body)
+	tests.AssertFalse(t, actual.Breaking)
+}
+
+func TestParseCommitTypeMustMatchExactlyError(t *testing.T) {
+	f := setup(t)
+	_, err := f.commitType.ParseCommit("hotfix: This is a sample message")
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, "change type not found", err.Error())
+
+	actualType, err := f.commitType.GetCommitChangeType("breaking changes(scope): This is a sample message")
+	tests.AssertNoError(t, err)
+	tests.AssertEqualValues(t, "breaking", actualType)
+}
@@ -0,0 +1,150 @@
+package committype
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+const (
+	breakingChangeToken      = "BREAKING CHANGE"
+	breakingChangeTokenAlias = "BREAKING-CHANGE"
+)
+
+var (
+	// headerPattern matches the Conventional Commits header. I.e.: feat(api,cli)!: Commit subject here.
+	headerPattern = regexp.MustCompile(`^([A-Za-z][A-Za-z -]*?)(?:\(([^()]*)\))?(!)?:\s*(.*?)\s*$`)
+
+	// footerPattern matches the first line of a footer. I.e.: Refs: #123, Reviewed-by: John or Fixes #123
+	footerPattern = regexp.MustCompile(`^(BREAKING CHANGE|BREAKING-CHANGE|[A-Za-z][A-Za-z0-9-]*)(?:: | #)(.*)$`)
+)
+
+// Footer is a trailer of the commit message. I.e.: BREAKING CHANGE: the config file format changed.
+type Footer struct {
+	Token string
+	Value string
+}
+
+// ParsedCommit is a commit message split following the Conventional Commits 1.0 specification.
+// See https://www.conventionalcommits.org/en/v1.0.0/
+type ParsedCommit struct {
+	Type     string
+	Scopes   []string
+	Breaking bool
+	Subject  string
+	Body     string
+	Footers  []Footer
+}
+
+func parseScopes(scopes string) []string {
+	var result []string
+	for _, scope := range strings.Split(scopes, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			result = append(result, scope)
+		}
+	}
+	return result
+}
+
+func isBreakingChangeToken(token string) bool {
+	return token == breakingChangeToken || token == breakingChangeTokenAlias
+}
+
+// splitFooters separates the body from the footers, which are the last paragraph of the message when it starts with a footer.
+func splitFooters(lines []string) ([]string, []Footer) {
+	start := len(lines)
+	for start > 0 && strings.TrimSpace(lines[start-1]) != "" {
+		start--
+	}
+
+	if start == len(lines) || !footerPattern.MatchString(lines[start]) {
+		return lines, nil
+	}
+
+	var footers []Footer
+	for _, line := range lines[start:] {
+		found := footerPattern.FindStringSubmatch(line)
+		if found == nil {
+			// a line that is not a footer continues the value of the previous one
+			footers[len(footers)-1].Value += "\n" + line
+			continue
+		}
+		footers = append(footers, Footer{Token: found[1], Value: found[2]})
+	}
+
+	return lines[:start], footers
+}
+
+// matchType returns the known commit type of a header. An exact match is preferred, otherwise a known type
+// written as a whole word is accepted to keep supporting legacy headers such as "breaking changes".
+func (c *CommitType) matchType(headerType string) (string, bool) {
+	headerType = strings.ToLower(strings.TrimSpace(headerType))
+	if hasStringInSlice(headerType, c.GetAll()) {
+		return headerType, true
+	}
+
+	words := strings.Fields(headerType)
+	for _, changeType := range c.GetAll() {
+		if hasStringInSlice(changeType, words) {
+			return changeType, true
+		}
+	}
+
+	return "", false
+}
+
+// ParseCommit parses a commit message following the Conventional Commits 1.0 specification.
+// Lines before the header, such as the ones added by merge requests, are ignored.
+// I.e.:
+//
+//	feat(api)!: Commit subject here.
+//
+//	Commit body here.
+//
+//	BREAKING CHANGE: the response format changed.
+//
+// Output: {Type: feat, Scopes: [api], Breaking: true, Subject: Commit subject here., Body: Commit body here., Footers: [{BREAKING CHANGE the response format changed.}]}
+func (c *CommitType) ParseCommit(commitMessage string) (*ParsedCommit, error) {
+	lines := strings.Split(strings.ReplaceAll(commitMessage, "\r\n", "\n"), "\n")
+
+	for i, line := range lines {
+		found := headerPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if found == nil {
+			continue
+		}
+
+		commitType, ok := c.matchType(found[1])
+		if !ok {
+			continue
+		}
+
+		bodyLines, footers := splitFooters(lines[i+1:])
+		parsed := &ParsedCommit{
+			Type:     commitType,
+			Scopes:   parseScopes(found[2]),
+			Breaking: found[3] == "!",
+			Subject:  found[4],
+			Body:     strings.TrimSpace(strings.Join(bodyLines, "\n")),
+			Footers:  footers,
+		}
+
+		for _, footer := range footers {
+			if isBreakingChangeToken(footer.Token) {
+				parsed.Breaking = true
+			}
+		}
+
+		return parsed, nil
+	}
+
+	return nil, errors.New("change type not found")
+}
+
+func hasStringInSlice(value string, slice []string) bool {
+	for i := range slice {
+		if slice[i] == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,342 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/NeowayLabs/semantic-release/src/constants"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultFileName is the configuration file looked up at the repository root.
+	DefaultFileName = ".semantic-release.yml"
+
+	// BumpMajor, BumpMinor, BumpPatch and BumpSkip are the upgrades a commit type may trigger.
+	BumpMajor = "major"
+	BumpMinor = "minor"
+	BumpPatch = "patch"
+	BumpSkip  = "skip"
+)
+
+// channelPattern matches a pre-release channel, which becomes the first identifier of the pre-release versions.
+var channelPattern = regexp.MustCompile(`^[A-Za-z][0-9A-Za-z-]*$`)
+
+// CommitType declares a commit type and the upgrade it triggers.
+type CommitType struct {
+	Type string `yaml:"type"`
+	Bump string `yaml:"bump"`
+}
+
+// VersionFile declares a file holding the release version. The path may be a glob pattern. I.e.: charts/*/values.yaml
+type VersionFile struct {
+	Path     string `yaml:"path"`
+	Variable string `yaml:"variable"`
+	// Occurrence is the assignment of Variable upgraded, counted from 1. It defaults to the first one.
+	Occurrence int `yaml:"occurrence"`
+	// Type is variable, the default, package-json, which upgrades the version of package.json and package-lock.json files,
+	// pyproject, which upgrades the version of the [project] or [tool.poetry] table of pyproject.toml files,
+	// regex, which upgrades the group named version of the matches of Pattern, or yaml, which upgrades the values of YAMLPaths.
+	Type string `yaml:"type"`
+	// Pattern is the regular expression of the regex type. I.e.: image: registry/app:(?P<version>.*)
+	Pattern string `yaml:"pattern"`
+	// Matches is the number of matches of Pattern expected in every file. Zero expects at least one.
+	Matches int `yaml:"matches"`
+	// YAMLPaths are the values upgraded by the yaml type. I.e.: image.tag or spec.containers[0].image
+	YAMLPaths []string `yaml:"yaml-paths"`
+}
+
+// PreReleaseBranch declares a branch that publishes pre-releases of a channel. I.e.: 1.4.0-beta.1
+// The channel defaults to the branch name.
+type PreReleaseBranch struct {
+	Branch  string `yaml:"branch"`
+	Channel string `yaml:"channel"`
+}
+
+// AssetLink declares a link attached to every release. I.e.: the package published to a registry
+type AssetLink struct {
+	Name string `yaml:"name"`
+	URL  string `yaml:"url"`
+	// Type is one of other, runbook, image or package. It defaults to other.
+	Type string `yaml:"type"`
+}
+
+// Release declares the release published in the git hosting provider after the tag is pushed.
+type Release struct {
+	// Provider is gitlab or github.
+	Provider string `yaml:"provider"`
+	// APIURL defaults to the API of the git host. I.e.: https://gitlab.com/api/v4 or https://api.github.com
+	APIURL string `yaml:"api-url"`
+	// Milestones and Assets are only supported by gitlab.
+	Milestones []string    `yaml:"milestones"`
+	Assets     []AssetLink `yaml:"assets"`
+	// Draft is only supported by github.
+	Draft bool `yaml:"draft"`
+}
+
+// Links declares how the changelog links commits and versions in the git hosting provider.
+type Links struct {
+	// Provider is gitlab, github or bitbucket. It defaults to the release provider or to the one detected from the git host.
+	Provider string `yaml:"provider"`
+	// Commit overrides the commit link. It accepts the {host}, {group}, {project} and {hash} placeholders.
+	Commit string `yaml:"commit"`
+	// Compare overrides the link comparing two versions. It accepts the {host}, {group}, {project}, {from} and {to} placeholders.
+	Compare string `yaml:"compare"`
+}
+
+// Config is the content of the .semantic-release.yml file.
+// I.e.:
+//
+//	changelog: docs/CHANGELOG.md
+//	tag-format: v{version}
+//	legacy-tags: true
+//	commit-types:
+//	  - type: feat
+//	    bump: minor
+//	  - type: fix
+//	    bump: patch
+//	version-files:
+//	  - path: setup.py
+//	    variable: __version__
+//	  - path: package.json
+//	    type: package-json
+//	prerelease-branches:
+//	  - branch: develop
+//	    channel: beta
+//	release:
+//	  provider: gitlab
+//	  milestones: [q1]
+//	  assets:
+//	    - name: docker image
+//	      url: https://registry.com/group/project
+//	      type: image
+//	links:
+//	  provider: bitbucket
+//	  commit: https://{host}/{group}/{project}/commits/{hash}
+type Config struct {
+	Changelog          string             `yaml:"changelog"`
+	TagFormat          string             `yaml:"tag-format"`
+	LegacyTags         bool               `yaml:"legacy-tags"`
+	CommitTypes        []CommitType       `yaml:"commit-types"`
+	VersionFiles       []VersionFile      `yaml:"version-files"`
+	PreReleaseBranches []PreReleaseBranch `yaml:"prerelease-branches"`
+	Release            Release            `yaml:"release"`
+	Links              Links              `yaml:"links"`
+}
+
+func (c *Config) validateCommitTypes() error {
+	types := make(map[string]bool)
+	for _, commitType := range c.CommitTypes {
+		name := strings.ToLower(strings.TrimSpace(commitType.Type))
+		if name == "" {
+			return errors.New("commit type cannot be empty")
+		}
+
+		if types[name] {
+			return fmt.Errorf("commit type %s is declared more than once", name)
+		}
+		types[name] = true
+
+		switch commitType.Bump {
+		case BumpMajor, BumpMinor, BumpPatch, BumpSkip:
+		default:
+			return fmt.Errorf("commit type %s has invalid bump %q. Expected major, minor, patch or skip", name, commitType.Bump)
+		}
+	}
+
+	return nil
+}
+
+func (c *Config) validateVersionFiles() error {
+	for _, versionFile := range c.VersionFiles {
+		if versionFile.Path == "" {
+			return errors.New("version file path cannot be empty")
+		}
+
+		switch versionFile.Type {
+		case "", constants.UpgradeTypeVariable:
+			if versionFile.Variable == "" {
+				return fmt.Errorf("version file %s must declare a variable", versionFile.Path)
+			}
+		case constants.UpgradeTypePackageJSON, constants.UpgradeTypePyproject:
+		case constants.UpgradeTypeRegex:
+			pattern, err := regexp.Compile(versionFile.Pattern)
+			if err != nil {
+				return fmt.Errorf("version file %s has invalid pattern due to: %w", versionFile.Path, err)
+			}
+
+			if versionFile.Pattern == "" || pattern.SubexpIndex(constants.VersionGroup) < 0 {
+				return fmt.Errorf("version file %s must declare a pattern with a group named %s. I.e.: (?P<%s>.*)", versionFile.Path, constants.VersionGroup, constants.VersionGroup)
+			}
+		case constants.UpgradeTypeYAML:
+			if len(versionFile.YAMLPaths) == 0 {
+				return fmt.Errorf("version file %s must declare yaml paths", versionFile.Path)
+			}
+		default:
+			return fmt.Errorf("version file %s has invalid type %q. Expected variable, package-json, pyproject, regex or yaml", versionFile.Path, versionFile.Type)
+		}
+
+		if versionFile.Occurrence < 0 {
+			return fmt.Errorf("version file %s has invalid occurrence %d", versionFile.Path, versionFile.Occurrence)
+		}
+
+		if versionFile.Matches < 0 {
+			return fmt.Errorf("version file %s cannot expect %d matches", versionFile.Path, versionFile.Matches)
+		}
+	}
+
+	return nil
+}
+
+func (c *Config) validatePreReleaseBranches() error {
+	branches := make(map[string]bool)
+	for _, preReleaseBranch := range c.PreReleaseBranches {
+		if preReleaseBranch.Branch == "" {
+			return errors.New("prerelease branch cannot be empty")
+		}
+
+		if branches[preReleaseBranch.Branch] {
+			return fmt.Errorf("prerelease branch %s is declared more than once", preReleaseBranch.Branch)
+		}
+		branches[preReleaseBranch.Branch] = true
+
+		channel := preReleaseBranch.GetChannel()
+		if !channelPattern.MatchString(channel) {
+			return fmt.Errorf("prerelease channel %s of branch %s must start with a letter and contain only alphanumerics and hyphens", channel, preReleaseBranch.Branch)
+		}
+	}
+
+	return nil
+}
+
+func (c *Config) validateRelease() error {
+	switch c.Release.Provider {
+	case "", constants.ProviderGitLab, constants.ProviderGitHub:
+	default:
+		return fmt.Errorf("release provider %s is not supported. Expected gitlab or github", c.Release.Provider)
+	}
+
+	for _, asset := range c.Release.Assets {
+		if asset.Name == "" || asset.URL == "" {
+			return errors.New("release asset must declare a name and an url")
+		}
+
+		switch asset.Type {
+		case "", constants.AssetLinkTypeOther, constants.AssetLinkTypeRunbook, constants.AssetLinkTypeImage, constants.AssetLinkTypePackage:
+		default:
+			return fmt.Errorf("release asset %s has invalid type %q. Expected other, runbook, image or package", asset.Name, asset.Type)
+		}
+	}
+
+	return nil
+}
+
+func (c *Config) validateLinks() error {
+	switch c.Links.Provider {
+	case "", constants.ProviderGitLab, constants.ProviderGitHub, constants.ProviderBitbucket:
+	default:
+		return fmt.Errorf("links provider %s is not supported. Expected gitlab, github or bitbucket", c.Links.Provider)
+	}
+
+	if c.Links.Commit != "" && !strings.Contains(c.Links.Commit, constants.HashPlaceholder) {
+		return fmt.Errorf("commit link %s must contain %s", c.Links.Commit, constants.HashPlaceholder)
+	}
+
+	if c.Links.Compare != "" && (!strings.Contains(c.Links.Compare, constants.FromPlaceholder) || !strings.Contains(c.Links.Compare, constants.ToPlaceholder)) {
+		return fmt.Errorf("compare link %s must contain %s and %s", c.Links.Compare, constants.FromPlaceholder, constants.ToPlaceholder)
+	}
+
+	return nil
+}
+
+// Validate checks the configuration values.
+func (c *Config) Validate() error {
+	if c.TagFormat != "" && strings.Count(c.TagFormat, constants.VersionPlaceholder) != 1 {
+		return fmt.Errorf("tag format %s must contain %s exactly once", c.TagFormat, constants.VersionPlaceholder)
+	}
+
+	if err := c.validateCommitTypes(); err != nil {
+		return err
+	}
+
+	if err := c.validateVersionFiles(); err != nil {
+		return err
+	}
+
+	if err := c.validatePreReleaseBranches(); err != nil {
+		return err
+	}
+
+	if err := c.validateRelease(); err != nil {
+		return err
+	}
+
+	return c.validateLinks()
+}
+
+// GetChannel returns the pre-release channel of the branch.
+func (p PreReleaseBranch) GetChannel() string {
+	if p.Channel != "" {
+		return p.Channel
+	}
+	return p.Branch
+}
+
+// GetPreReleaseChannel returns the pre-release channel of a branch. It returns false when the branch publishes stable releases.
+func (c *Config) GetPreReleaseChannel(branch string) (string, bool) {
+	for _, preReleaseBranch := range c.PreReleaseBranches {
+		if preReleaseBranch.Branch == branch {
+			return preReleaseBranch.GetChannel(), true
+		}
+	}
+	return "", false
+}
+
+// GetCommitTypesByBump groups the declared commit types by the upgrade they trigger.
+// I.e.: map[minor:[feat feature] patch:[fix]]
+func (c *Config) GetCommitTypesByBump() map[string][]string {
+	result := make(map[string][]string)
+	for _, commitType := range c.CommitTypes {
+		name := strings.ToLower(strings.TrimSpace(commitType.Type))
+		result[commitType.Bump] = append(result[commitType.Bump], name)
+	}
+	return result
+}
+
+// Parse reads and validates a configuration. Unknown keys are rejected so typos do not go unnoticed.
+func Parse(content []byte) (*Config, error) {
+	var config Config
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("error while parsing configuration due to: %w", err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return &config, nil
+}
+
+// Load reads the configuration file at path.
+// The returned error wraps os.ErrNotExist when the file does not exist.
+func Load(path string) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading configuration file due to: %w", err)
+	}
+
+	config, err := Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("error while loading %s: %w", path, err)
+	}
+
+	return config, nil
+}
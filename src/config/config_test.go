@@ -0,0 +1,193 @@
+//go:build unit
+// +build unit
+
+package config_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/NeowayLabs/semantic-release/src/config"
+	"github.com/NeowayLabs/semantic-release/src/tests"
+)
+
+func TestLoadNoError(t *testing.T) {
+	cfg, err := config.Load("mock/semantic-release.yml")
+	tests.AssertNoError(t, err)
+	tests.AssertEqualValues(t, "docs/CHANGELOG.md", cfg.Changelog)
+	tests.AssertEqualValues(t, "v{version}", cfg.TagFormat)
+	tests.AssertTrue(t, cfg.LegacyTags)
+	tests.AssertDeepEqualValues(t, []config.VersionFile{{Path: "setup.py", Variable: "__version__"}}, cfg.VersionFiles)
+
+	expected := map[string][]string{
+		"major": {"breaking"},
+		"minor": {"feat"},
+		"patch": {"fix", "docs"},
+		"skip":  {"chore"},
+	}
+	tests.AssertDeepEqualValues(t, expected, cfg.GetCommitTypesByBump())
+
+	channel, found := cfg.GetPreReleaseChannel("develop")
+	tests.AssertTrue(t, found)
+	tests.AssertEqualValues(t, "beta", channel)
+
+	channel, found = cfg.GetPreReleaseChannel("next")
+	tests.AssertTrue(t, found)
+	tests.AssertEqualValues(t, "next", channel)
+
+	_, found = cfg.GetPreReleaseChannel("main")
+	tests.AssertFalse(t, found)
+
+	expectedRelease := config.Release{
+		Provider:   "gitlab",
+		Milestones: []string{"q1"},
+		Assets:     []config.AssetLink{{Name: "docker image", URL: "https://registry.com/group/project", Type: "image"}},
+	}
+	tests.AssertDeepEqualValues(t, expectedRelease, cfg.Release)
+}
+
+func TestLoadFileNotFoundError(t *testing.T) {
+	_, err := config.Load("mock/not-found.yml")
+	tests.AssertError(t, err)
+	tests.AssertTrue(t, errors.Is(err, os.ErrNotExist))
+}
+
+func TestParseEmptyNoError(t *testing.T) {
+	cfg, err := config.Parse([]byte(""))
+	tests.AssertNoError(t, err)
+	tests.AssertDeepEqualValues(t, &config.Config{}, cfg)
+}
+
+func TestParseUnknownFieldError(t *testing.T) {
+	_, err := config.Parse([]byte("change-log: CHANGELOG.md\n"))
+	tests.AssertError(t, err)
+}
+
+func TestParseInvalidTagFormatError(t *testing.T) {
+	_, err := config.Parse([]byte("tag-format: v1\n"))
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, "invalid configuration: tag format v1 must contain {version} exactly once", err.Error())
+}
+
+func TestParseInvalidBumpError(t *testing.T) {
+	_, err := config.Parse([]byte("commit-types:\n  - type: feat\n    bump: huge\n"))
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, "invalid configuration: commit type feat has invalid bump \"huge\". Expected major, minor, patch or skip", err.Error())
+}
+
+func TestParseDuplicatedCommitTypeError(t *testing.T) {
+	_, err := config.Parse([]byte("commit-types:\n  - type: feat\n    bump: minor\n  - type: Feat\n    bump: patch\n"))
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, "invalid configuration: commit type feat is declared more than once", err.Error())
+}
+
+func TestParseEmptyCommitTypeError(t *testing.T) {
+	_, err := config.Parse([]byte("commit-types:\n  - bump: minor\n"))
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, "invalid configuration: commit type cannot be empty", err.Error())
+}
+
+func TestParseVersionFileWithoutVariableError(t *testing.T) {
+	_, err := config.Parse([]byte("version-files:\n  - path: setup.py\n"))
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, "invalid configuration: version file setup.py must declare a variable", err.Error())
+}
+
+func TestParsePackageJSONVersionFileNoError(t *testing.T) {
+	cfg, err := config.Parse([]byte("version-files:\n  - path: package.json\n    type: package-json\n"))
+	tests.AssertNoError(t, err)
+	tests.AssertDeepEqualValues(t, []config.VersionFile{{Path: "package.json", Type: "package-json"}}, cfg.VersionFiles)
+}
+
+func TestParseInvalidVersionFileTypeError(t *testing.T) {
+	_, err := config.Parse([]byte("version-files:\n  - path: pom.xml\n    type: maven\n"))
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, "invalid configuration: version file pom.xml has invalid type \"maven\". Expected variable, package-json, pyproject, regex or yaml", err.Error())
+}
+
+func TestParseVariableOccurrenceNoError(t *testing.T) {
+	cfg, err := config.Parse([]byte("version-files:\n  - path: version.go\n    variable: Version\n    occurrence: 2\n"))
+	tests.AssertNoError(t, err)
+	tests.AssertDeepEqualValues(t, []config.VersionFile{{Path: "version.go", Variable: "Version", Occurrence: 2}}, cfg.VersionFiles)
+
+	_, err = config.Parse([]byte("version-files:\n  - path: version.go\n    variable: Version\n    occurrence: -1\n"))
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, "invalid configuration: version file version.go has invalid occurrence -1", err.Error())
+}
+
+func TestParseRegexVersionFileNoError(t *testing.T) {
+	cfg, err := config.Parse([]byte("version-files:\n  - path: deploy/*.yaml\n    type: regex\n    pattern: 'image: registry/app:(?P<version>.*)'\n    matches: 2\n"))
+	tests.AssertNoError(t, err)
+	tests.AssertDeepEqualValues(t, []config.VersionFile{{Path: "deploy/*.yaml", Type: "regex", Pattern: "image: registry/app:(?P<version>.*)", Matches: 2}}, cfg.VersionFiles)
+}
+
+func TestParseInvalidRegexVersionFileError(t *testing.T) {
+	_, err := config.Parse([]byte("version-files:\n  - path: Makefile\n    type: regex\n    pattern: 'VERSION=(.*)'\n"))
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, "invalid configuration: version file Makefile must declare a pattern with a group named version. I.e.: (?P<version>.*)", err.Error())
+
+	_, err = config.Parse([]byte("version-files:\n  - path: Makefile\n    type: regex\n    pattern: 'VERSION=(?P<version>.*'\n"))
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, "invalid configuration: version file Makefile has invalid pattern due to: error parsing regexp: missing closing ): `VERSION=(?P<version>.*`", err.Error())
+
+	_, err = config.Parse([]byte("version-files:\n  - path: Makefile\n    type: regex\n    pattern: 'VERSION=(?P<version>.*)'\n    matches: -1\n"))
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, "invalid configuration: version file Makefile cannot expect -1 matches", err.Error())
+}
+
+func TestParseYAMLVersionFileNoError(t *testing.T) {
+	cfg, err := config.Parse([]byte("version-files:\n  - path: chart/Chart.yaml\n    type: yaml\n    yaml-paths: [version, appVersion]\n"))
+	tests.AssertNoError(t, err)
+	tests.AssertDeepEqualValues(t, []config.VersionFile{{Path: "chart/Chart.yaml", Type: "yaml", YAMLPaths: []string{"version", "appVersion"}}}, cfg.VersionFiles)
+
+	_, err = config.Parse([]byte("version-files:\n  - path: chart/values.yaml\n    type: yaml\n"))
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, "invalid configuration: version file chart/values.yaml must declare yaml paths", err.Error())
+}
+
+func TestParseInvalidPreReleaseChannelError(t *testing.T) {
+	_, err := config.Parse([]byte("prerelease-branches:\n  - branch: release/1.x\n"))
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, "invalid configuration: prerelease channel release/1.x of branch release/1.x must start with a letter and contain only alphanumerics and hyphens", err.Error())
+}
+
+func TestParseGitHubReleaseNoError(t *testing.T) {
+	cfg, err := config.Parse([]byte("release:\n  provider: github\n  api-url: https://github.example.com/api/v3\n  draft: true\n"))
+	tests.AssertNoError(t, err)
+	tests.AssertDeepEqualValues(t, config.Release{Provider: "github", APIURL: "https://github.example.com/api/v3", Draft: true}, cfg.Release)
+}
+
+func TestParseInvalidReleaseError(t *testing.T) {
+	_, err := config.Parse([]byte("release:\n  provider: bitbucket\n"))
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, "invalid configuration: release provider bitbucket is not supported. Expected gitlab or github", err.Error())
+
+	_, err = config.Parse([]byte("release:\n  provider: gitlab\n  assets:\n    - name: binary\n"))
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, "invalid configuration: release asset must declare a name and an url", err.Error())
+
+	_, err = config.Parse([]byte("release:\n  provider: gitlab\n  assets:\n    - name: binary\n      url: https://host/binary\n      type: zip\n"))
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, "invalid configuration: release asset binary has invalid type \"zip\". Expected other, runbook, image or package", err.Error())
+}
+
+func TestParseLinksNoError(t *testing.T) {
+	cfg, err := config.Parse([]byte("links:\n  provider: bitbucket\n  commit: https://{host}/{group}/{project}/commits/{hash}\n"))
+	tests.AssertNoError(t, err)
+	tests.AssertDeepEqualValues(t, config.Links{Provider: "bitbucket", Commit: "https://{host}/{group}/{project}/commits/{hash}"}, cfg.Links)
+}
+
+func TestParseInvalidLinksError(t *testing.T) {
+	_, err := config.Parse([]byte("links:\n  provider: gitea\n"))
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, "invalid configuration: links provider gitea is not supported. Expected gitlab, github or bitbucket", err.Error())
+
+	_, err = config.Parse([]byte("links:\n  commit: https://{host}/{group}/{project}/commit\n"))
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, "invalid configuration: commit link https://{host}/{group}/{project}/commit must contain {hash}", err.Error())
+
+	_, err = config.Parse([]byte("links:\n  compare: https://{host}/{group}/{project}/compare/{from}\n"))
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, "invalid configuration: compare link https://{host}/{group}/{project}/compare/{from} must contain {from} and {to}", err.Error())
+}
@@ -0,0 +1,46 @@
+// Package constants holds the values shared by the configuration file and the packages consuming it.
+// It imports nothing, so any package may depend on it.
+package constants
+
+// Upgrade types of the version files.
+const (
+	// UpgradeTypeVariable upgrades the value assigned to a variable, such as __version__ = "1.0.0". It is the default type.
+	UpgradeTypeVariable = "variable"
+	// UpgradeTypePackageJSON upgrades the version of a package.json or package-lock.json file.
+	UpgradeTypePackageJSON = "package-json"
+	// UpgradeTypePyproject upgrades the version of the [project] or [tool.poetry] table of a pyproject.toml file.
+	UpgradeTypePyproject = "pyproject"
+	// UpgradeTypeRegex upgrades the group named version of every match of a regular expression.
+	UpgradeTypeRegex = "regex"
+	// UpgradeTypeYAML upgrades the values found by following YAML paths. I.e.: image.tag
+	UpgradeTypeYAML = "yaml"
+
+	// VersionGroup is the named group of the regular expressions matching the version to upgrade.
+	VersionGroup = "version"
+)
+
+// Git hosting providers the releases are published to and the changelog links are rendered for.
+const (
+	ProviderGitLab    = "gitlab"
+	ProviderGitHub    = "github"
+	ProviderBitbucket = "bitbucket"
+)
+
+// Placeholders of the templates.
+const (
+	// VersionPlaceholder is replaced by the version in the tag format. I.e.: v{version}
+	VersionPlaceholder = "{version}"
+	// HashPlaceholder is replaced by the commit hash in the commit link.
+	HashPlaceholder = "{hash}"
+	// FromPlaceholder and ToPlaceholder are replaced by the tags compared in the compare link.
+	FromPlaceholder = "{from}"
+	ToPlaceholder   = "{to}"
+)
+
+// Types of the links attached to a release.
+const (
+	AssetLinkTypeOther   = "other"
+	AssetLinkTypeRunbook = "runbook"
+	AssetLinkTypeImage   = "image"
+	AssetLinkTypePackage = "package"
+)
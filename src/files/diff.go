@@ -0,0 +1,136 @@
+package files
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// diffContextLines is the number of unchanged lines printed around each change.
+const diffContextLines = 3
+
+type diffLine struct {
+	operation byte
+	text      string
+}
+
+func splitDiffLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	for i := range lines {
+		lines[i] = strings.TrimSuffix(lines[i], "\n")
+	}
+
+	return lines
+}
+
+func toDiffLines(before, after string) []diffLine {
+	var lines []diffLine
+	for _, change := range diff.Do(before, after) {
+		operation := byte(' ')
+		switch change.Type {
+		case diffmatchpatch.DiffDelete:
+			operation = '-'
+		case diffmatchpatch.DiffInsert:
+			operation = '+'
+		}
+
+		for _, text := range splitDiffLines(change.Text) {
+			lines = append(lines, diffLine{operation: operation, text: text})
+		}
+	}
+
+	return lines
+}
+
+// hunkEnd returns the index right after the hunk that has its first change at start.
+// Changes separated by less than two contexts of unchanged lines are merged into the same hunk.
+func hunkEnd(lines []diffLine, start int) int {
+	end := start
+	for end < len(lines) {
+		if lines[end].operation != ' ' {
+			end++
+			continue
+		}
+
+		next := end
+		for next < len(lines) && lines[next].operation == ' ' {
+			next++
+		}
+
+		if next == len(lines) || next-end > 2*diffContextLines {
+			if end+diffContextLines < len(lines) {
+				return end + diffContextLines
+			}
+			return len(lines)
+		}
+		end = next
+	}
+
+	return end
+}
+
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start-1)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// unifiedDiff renders the changes from before to after as a unified diff of the file at path.
+// It returns an empty string when both contents are equal.
+func unifiedDiff(path, before, after string) string {
+	if before == after {
+		return ""
+	}
+
+	lines := toDiffLines(before, after)
+
+	// oldLine and newLine hold the line number of each diff line in the original and the new content.
+	oldLine := make([]int, len(lines)+1)
+	newLine := make([]int, len(lines)+1)
+	oldLine[0], newLine[0] = 1, 1
+	for i, line := range lines {
+		oldLine[i+1], newLine[i+1] = oldLine[i], newLine[i]
+		if line.operation != '+' {
+			oldLine[i+1]++
+		}
+		if line.operation != '-' {
+			newLine[i+1]++
+		}
+	}
+
+	var output strings.Builder
+	fmt.Fprintf(&output, "--- a/%s\n+++ b/%s\n", path, path)
+
+	for i := 0; i < len(lines); {
+		for i < len(lines) && lines[i].operation == ' ' {
+			i++
+		}
+		if i == len(lines) {
+			break
+		}
+
+		start := i - diffContextLines
+		if start < 0 {
+			start = 0
+		}
+		end := hunkEnd(lines, i)
+
+		fmt.Fprintf(&output, "@@ -%s +%s @@\n",
+			hunkRange(oldLine[start], oldLine[end]-oldLine[start]),
+			hunkRange(newLine[start], newLine[end]-newLine[start]))
+		for _, line := range lines[start:end] {
+			fmt.Fprintf(&output, "%c%s\n", line.operation, line.text)
+		}
+
+		i = end
+	}
+
+	return output.String()
+}
@@ -0,0 +1,5 @@
+package files
+
+func UnifiedDiff(path, before, after string) string {
+	return unifiedDiff(path, before, after)
+}
@@ -5,13 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"strings"
-)
 
-const (
-	colorYellow = "\033[33m"
-	colorReset  = "\033[0m"
+	"github.com/NeowayLabs/semantic-release/src/constants"
 )
 
 var (
@@ -26,6 +25,7 @@ type Logger interface {
 
 type CommitMessageManager interface {
 	PrettifyCommitMessage(commitMessage string) (string, error)
+	IsBreakingChange(commitMessage string) bool
 }
 
 type ElapsedTime func(functionName string) func()
@@ -38,6 +38,19 @@ type ChangesInfo struct {
 	CurrentVersion string
 	NewVersion     string
 	ChangeType     string
+	Changes        []ChangeInfo
+	// CurrentTag and NewTag are compared in the changelog. CurrentTag is empty for the first release.
+	CurrentTag string
+	NewTag     string
+}
+
+// ChangeInfo describes one of the commits included in a release.
+type ChangeInfo struct {
+	Hash        string
+	AuthorName  string
+	AuthorEmail string
+	Message     string
+	ChangeType  string
 }
 
 type UpgradeFiles struct {
@@ -48,6 +61,16 @@ type UpgradeFile struct {
 	Path            string
 	DestinationPath string
 	VariableName    string
+	// Occurrence is the assignment of VariableName upgraded, counted from 1. It defaults to the first one.
+	Occurrence int
+	// Type is how the version is upgraded. It defaults to constants.UpgradeTypeVariable.
+	Type string
+	// Pattern is the regular expression of files of type regex. I.e.: image: registry/app:(?P<version>.*)
+	Pattern string
+	// Matches is the number of matches of Pattern expected in the file. Zero expects at least one.
+	Matches int
+	// YAMLPaths are the values upgraded in files of type yaml. I.e.: version and appVersion of a Chart.yaml
+	YAMLPaths []string
 }
 
 type FileVersion struct {
@@ -57,8 +80,10 @@ type FileVersion struct {
 	repositoryRootPath   string
 	groupName            string
 	projectName          string
-	variableNameFound    bool
 	commitMessageManager CommitMessageManager
+	dryRun               bool
+	diffOutput           io.Writer
+	linkTemplates        LinkTemplates
 }
 
 func (f *FileVersion) openFile(filePath string) (*os.File, error) {
@@ -69,11 +94,6 @@ func (f *FileVersion) openFile(filePath string) (*os.File, error) {
 	return file, nil
 }
 
-func (f *FileVersion) containsVariableNameInText(text, variableName, copySignal string) bool {
-	text = strings.ReplaceAll(text, " ", "")
-	return strings.Contains(text, fmt.Sprintf("%s%s", variableName, copySignal))
-}
-
 func (f *FileVersion) setDefaultPath(path, newPath string) string {
 	if path != "" {
 		return path
@@ -98,6 +118,10 @@ func (f *FileVersion) unmarshalUpgradeFiles(filesToUpgrade interface{}) (*Upgrad
 func (f *FileVersion) writeFile(destinationPath, originPath string, content []byte) error {
 	destination := f.setDefaultPath(destinationPath, originPath)
 
+	if f.dryRun {
+		return f.printFileDiff(destination, content)
+	}
+
 	if err := os.WriteFile(destination, content, 0666); err != nil {
 		return fmt.Errorf("error while writing file %s due to: %w", destination, err)
 	}
@@ -105,41 +129,92 @@ func (f *FileVersion) writeFile(destinationPath, originPath string, content []by
 	return nil
 }
 
-func (f *FileVersion) getFileOutputContent(scanner *bufio.Scanner, file UpgradeFile, newVersion string) ([]byte, error) {
-	var outputData []byte
+// diffPath returns path relative to the repository root, as the headers of a diff applied with git apply or patch -p1 expect.
+func (f *FileVersion) diffPath(path string) string {
+	relativePath, err := filepath.Rel(f.repositoryRootPath, path)
+	if err != nil || strings.HasPrefix(relativePath, "..") {
+		return strings.TrimPrefix(filepath.ToSlash(path), "/")
+	}
+	return filepath.ToSlash(relativePath)
+}
 
-	f.variableNameFound = false
-	for scanner.Scan() {
-		currentLineText := scanner.Text()
-		outputLineText := []byte(fmt.Sprintf("%s\n", currentLineText))
+// printFileDiff writes the unified diff between the current content of path and the given content to the diff output.
+func (f *FileVersion) printFileDiff(path string, content []byte) error {
+	currentContent, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error while reading file %s due to: %w", path, err)
+	}
 
-		if f.containsVariableNameInText(currentLineText, file.VariableName, ":=") {
-			outputLineText = []byte(fmt.Sprintf("%s := \"%s\"\n", file.VariableName, newVersion))
-			f.variableNameFound = true
-		}
+	f.log.Info("dry run: printing the changes that would be written to %s", path)
+	if _, err := io.WriteString(f.diffOutput, unifiedDiff(f.diffPath(path), string(currentContent), string(content))); err != nil {
+		return fmt.Errorf("error while printing the changes of file %s due to: %w", path, err)
+	}
+	return nil
+}
 
-		if f.containsVariableNameInText(currentLineText, file.VariableName, "=") {
-			outputLineText = []byte(fmt.Sprintf("%s = \"%s\"\n", file.VariableName, newVersion))
-			f.variableNameFound = true
-		}
+func (f *FileVersion) upgradeVariable(file UpgradeFile, newVersion string) ([]byte, error) {
+	content, err := os.ReadFile(file.Path)
+	if err != nil {
+		return nil, fmt.Errorf("error while oppening file due to: %w", err)
+	}
+
+	outputData, err := f.getFileOutputContent(content, file, newVersion)
+	if err != nil {
+		return nil, fmt.Errorf("error while getting file output data due to: %w", err)
+	}
+
+	return outputData, nil
+}
 
-		outputData = append(outputData, outputLineText...)
+// upgradeContent reads the whole file and upgrades the version with upgrade.
+func (f *FileVersion) upgradeContent(file UpgradeFile, newVersion string, upgrade func(content []byte, newVersion string) ([]byte, error)) ([]byte, error) {
+	content, err := os.ReadFile(file.Path)
+	if err != nil {
+		return nil, fmt.Errorf("error while oppening file due to: %w", err)
 	}
 
-	if !f.variableNameFound {
-		return nil, fmt.Errorf("variable name `%s` not found on file `%s`", file.VariableName, file.Path)
+	outputData, err := upgrade(content, newVersion)
+	if err != nil {
+		return nil, fmt.Errorf("error while upgrading version in file %s due to: %w", file.Path, err)
 	}
 
 	return outputData, nil
 }
 
+// upgradeFile returns the content of the file with the new version, according to the type of the file.
+func (f *FileVersion) upgradeFile(file UpgradeFile, newVersion string) ([]byte, error) {
+	switch file.Type {
+	case "", constants.UpgradeTypeVariable:
+		return f.upgradeVariable(file, newVersion)
+	case constants.UpgradeTypePackageJSON:
+		return f.upgradeContent(file, newVersion, upgradePackageJSON)
+	case constants.UpgradeTypePyproject:
+		return f.upgradeContent(file, newVersion, upgradePyproject)
+	case constants.UpgradeTypeRegex:
+		return f.upgradeContent(file, newVersion, func(content []byte, newVersion string) ([]byte, error) {
+			return upgradeRegex(content, file.Pattern, file.Matches, newVersion)
+		})
+	case constants.UpgradeTypeYAML:
+		return f.upgradeContent(file, newVersion, func(content []byte, newVersion string) ([]byte, error) {
+			return upgradeYAML(content, file.YAMLPaths, newVersion)
+		})
+	}
+
+	return nil, fmt.Errorf("upgrade type %s of file %s is not supported", file.Type, file.Path)
+}
+
 // UpgradeVariableInFiles aims to update given files with the new release version.
-// It will update the files row containing a given variable name.
+// It will update the value assigned to a given variable name, in its first or declared occurrence, keeping the rest of the file.
 // I.e.:
 // err := UpgradeVariableInFiles(UpgradeFiles{Files: []UpgradeFile{{Path: "./setup.py", DestinationPath: "", VariableName: "__version__"}}), "1.0.1")
 //
 //	From: __version__ = 1.0.0
 //	To:   __version__ = 1.0.1
+//
+// Files of type package-json and pyproject have their version values upgraded instead. See upgradePackageJSON and upgradePyproject
+// Files of type regex have the version group of their pattern upgraded. See upgradeRegex
+// Files of type yaml have the values of their YAML paths upgraded. See upgradeYAML
+// Paths may be glob patterns, such as deploy/*.yaml, upgrading every file matched.
 func (f *FileVersion) UpgradeVariableInFiles(filesToUpgrade interface{}, newVersion string) error {
 	defer f.elapsedTime("UpgradeVariableInFiles")()
 
@@ -148,24 +223,17 @@ func (f *FileVersion) UpgradeVariableInFiles(filesToUpgrade interface{}, newVers
 		return fmt.Errorf("error unmarshalling files to upgrade due to: %w", err)
 	}
 
-	for _, currentFile := range filesToUpdate.Files {
-		f.log.Info(colorYellow+"Upgrading version variable in %s file"+colorReset, currentFile.Path)
-
-		file, err := f.openFile(currentFile.Path)
-		if err != nil {
-			return err
-		}
-		defer file.Close()
+	upgradeFiles, err := expandGlobs(filesToUpdate.Files)
+	if err != nil {
+		return err
+	}
 
-		scanner := bufio.NewScanner(file)
+	for _, currentFile := range upgradeFiles {
+		f.log.Info("Upgrading version variable in %s file", currentFile.Path)
 
-		outputData, err := f.getFileOutputContent(scanner, currentFile, newVersion)
+		outputData, err := f.upgradeFile(currentFile, newVersion)
 		if err != nil {
-			return fmt.Errorf("error while getting file output data due to: %w", err)
-		}
-
-		if err := scanner.Err(); err != nil {
-			return fmt.Errorf("error while scanning file %s due to: %w", currentFile.Path, err)
+			return err
 		}
 
 		if err = f.writeFile(currentFile.DestinationPath, currentFile.Path, outputData); err != nil {
@@ -213,10 +281,6 @@ func (f *FileVersion) abbreviateHash(hash string) string {
 	return hash[:7]
 }
 
-func (f *FileVersion) getCommitUrl(changelog ChangesInfo) string {
-	return fmt.Sprintf("[%s](https://%s/%s/%s/commit/%s)", f.abbreviateHash(changelog.Hash), f.versionConrolHost, f.groupName, f.projectName, changelog.Hash)
-}
-
 func (f *FileVersion) prettifyEmail(email string) string {
 	splitedEmail := strings.Split(email, "@")
 	return fmt.Sprintf("@%s", splitedEmail[0])
@@ -236,22 +300,84 @@ func (f *FileVersion) unmarshalChangesInfo(changes interface{}) (*ChangesInfo, e
 	return &changelog, nil
 }
 
-func (f *FileVersion) formatChangeLogContent(changes *ChangesInfo) (string, error) {
-	commitMessage, err := f.commitMessageManager.PrettifyCommitMessage(changes.Message)
+func (f *FileVersion) formatChangeLogLine(change ChangeInfo) (string, error) {
+	if len(change.Hash) < 7 {
+		return "", errors.New("hash string must have at least 7 characters")
+	}
+
+	commitMessage, err := f.commitMessageManager.PrettifyCommitMessage(change.Message)
 	if err != nil {
 		return "", fmt.Errorf("prettify commit message error: %w", err)
 	}
 
+	// breaking changes are highlighted as in Conventional Commits. I.e.: feat!
+	changeType := change.ChangeType
+	if f.commitMessageManager.IsBreakingChange(change.Message) {
+		changeType += "!"
+	}
+
+	return fmt.Sprintf("- %s - %s: %s (%s)\n",
+		changeType,
+		f.getCommitUrl(change.Hash),
+		commitMessage,
+		f.prettifyEmail(change.AuthorEmail)), nil
+}
+
+// formatChangeLogSection renders the release title and one line per change, without the separator of releases.
+func (f *FileVersion) formatChangeLogSection(changes *ChangesInfo) (string, error) {
+	releaseChanges := changes.Changes
+	if len(releaseChanges) == 0 {
+		releaseChanges = []ChangeInfo{{
+			Hash:        changes.Hash,
+			AuthorName:  changes.AuthorName,
+			AuthorEmail: changes.AuthorEmail,
+			Message:     changes.Message,
+			ChangeType:  changes.ChangeType,
+		}}
+	}
+
 	// textToAdd
 	// I.e.:
 	// ## v1.0.0:
 	// - feat - [b25a9af](https://gilabhost/groupName/projectName/commit/b25a9af78c30de0d03ca2ee6d18c66bbc4804395): Commit message here (@user.name)
-	return fmt.Sprintf("\n## v%s\n- %s - %s: %s (%s)\n---\n\n",
-		changes.NewVersion,
-		changes.ChangeType,
-		f.getCommitUrl(*changes),
-		commitMessage,
-		f.prettifyEmail(changes.AuthorEmail)), nil
+	// - fix - [a13b7c2](https://gilabhost/groupName/projectName/commit/a13b7c2f9d1e0b3c8a7d6e5f4a3b2c1d0e9f8a7b): Another commit message here (@user.name)
+	content := fmt.Sprintf("## v%s\n", changes.NewVersion)
+	for _, change := range releaseChanges {
+		line, err := f.formatChangeLogLine(change)
+		if err != nil {
+			return "", err
+		}
+		content += line
+	}
+
+	if compareLink := f.getCompareLink(changes.CurrentTag, changes.NewTag); compareLink != "" {
+		content += fmt.Sprintf("\n**Full Changelog**: %s\n", compareLink)
+	}
+
+	return content, nil
+}
+
+func (f *FileVersion) formatChangeLogContent(changes *ChangesInfo) (string, error) {
+	section, err := f.formatChangeLogSection(changes)
+	if err != nil {
+		return "", err
+	}
+
+	return "\n" + section + "---\n\n", nil
+}
+
+// GetReleaseNotes renders the changelog section of the release, used as the description of the release in the git hosting provider.
+func (f *FileVersion) GetReleaseNotes(chageLogInfo interface{}) (string, error) {
+	changelog, err := f.unmarshalChangesInfo(chageLogInfo)
+	if err != nil {
+		return "", fmt.Errorf("error unmarshalling changes info due to: %w", err)
+	}
+
+	if err := f.validateChangesInfo(*changelog); err != nil {
+		return "", fmt.Errorf("error validating changelog info due to: %w", err)
+	}
+
+	return f.formatChangeLogSection(changelog)
 }
 
 // UpgradeChangelog aims to append the new release version with the commit information to the CHANGELOG.md file.
@@ -260,7 +386,7 @@ func (f *FileVersion) UpgradeChangeLog(path, destinationPath string, chageLogInf
 
 	originPath := f.setDefaultPath(path, fmt.Sprintf("%s/%s", f.repositoryRootPath, changeLogDefaultFile))
 
-	f.log.Info(colorYellow+"Upgrading %s file"+colorReset, originPath)
+	f.log.Info("Upgrading %s file", originPath)
 
 	changelog, err := f.unmarshalChangesInfo(chageLogInfo)
 	if err != nil {
@@ -295,13 +421,29 @@ func (f *FileVersion) UpgradeChangeLog(path, destinationPath string, chageLogInf
 		return fmt.Errorf("\n\nerror while scanning file: %s due to: %w", originPath, err)
 	}
 
-	if err = f.writeFile(destinationPath, path, outputData); err != nil {
+	if err = f.writeFile(destinationPath, originPath, outputData); err != nil {
 		return fmt.Errorf("error while writing new version to changelog file due to: %w", err)
 	}
 
 	return nil
 }
 
+// SetLinkTemplates changes how the commit and compare links of the changelog are rendered.
+// They default to the templates of the provider detected from the host. See DetectProvider
+func (f *FileVersion) SetLinkTemplates(linkTemplates LinkTemplates) {
+	f.linkTemplates = linkTemplates
+}
+
+// SetDryRun makes the files version control print the changes as unified diffs instead of writing them.
+func (f *FileVersion) SetDryRun(dryRun bool) {
+	f.dryRun = dryRun
+}
+
+// SetDiffOutput changes where the unified diffs of the dry run are written. It defaults to stdout.
+func (f *FileVersion) SetDiffOutput(diffOutput io.Writer) {
+	f.diffOutput = diffOutput
+}
+
 func New(log Logger, elapsedTime ElapsedTime, versionConrolHost, repositoryRootPath, groupName, projectName string, commitMessageManager CommitMessageManager) *FileVersion {
 	return &FileVersion{
 		log:                  log,
@@ -311,5 +453,7 @@ func New(log Logger, elapsedTime ElapsedTime, versionConrolHost, repositoryRootP
 		groupName:            groupName,
 		projectName:          projectName,
 		commitMessageManager: commitMessageManager,
+		diffOutput:           os.Stdout,
+		linkTemplates:        providerLinkTemplates[DetectProvider(versionConrolHost)],
 	}
 }
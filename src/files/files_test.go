@@ -4,11 +4,16 @@
 package files_test
 
 import (
+	"bytes"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	commitmessage "github.com/NeowayLabs/semantic-release/src/commit-message"
 	committype "github.com/NeowayLabs/semantic-release/src/commit-type"
+	"github.com/NeowayLabs/semantic-release/src/constants"
 	"github.com/NeowayLabs/semantic-release/src/files"
 	"github.com/NeowayLabs/semantic-release/src/log"
 	"github.com/NeowayLabs/semantic-release/src/tests"
@@ -22,6 +27,17 @@ type ChangesInfoMock struct {
 	CurrentVersion string
 	NewVersion     string
 	ChangeType     string
+	Changes        []ChangeInfoMock
+	CurrentTag     string
+	NewTag         string
+}
+
+type ChangeInfoMock struct {
+	Hash        string
+	AuthorName  string
+	AuthorEmail string
+	Message     string
+	ChangeType  string
 }
 
 type UpgradeFilesMock struct {
@@ -32,6 +48,11 @@ type UpgradeFileMock struct {
 	Path            string
 	DestinationPath string
 	VariableName    string
+	Occurrence      int
+	Type            string
+	Pattern         string
+	Matches         int
+	YAMLPaths       []string
 }
 
 func printElapsedTimeMock(functionName string) func() {
@@ -64,11 +85,41 @@ func (f *fixture) newFiles() *files.FileVersion {
 	return files.New(f.log, printElapsedTimeMock, f.versionControlHost, f.repositoryRootPath, f.groupName, f.projectName, commitMessageManager)
 }
 
+// copyMock copies a file of the mock directory to a temporary directory, so the tests writing to it keep the mock unchanged.
+func copyMock(t *testing.T, name string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join("mock", name))
+	tests.AssertNoError(t, err)
+
+	path := filepath.Join(t.TempDir(), name)
+	tests.AssertNoError(t, os.WriteFile(path, content, 0666))
+	return path
+}
+
+// upgradeVariableMock writes content to a temporary file named name, upgrades it to 1.1.0 as file declares and returns its content.
+// When the upgrade fails, it returns the path of the file.
+func upgradeVariableMock(t *testing.T, name, content string, file UpgradeFileMock) (string, error) {
+	f := setup(t)
+	filesVersion := f.newFiles()
+
+	path := filepath.Join(t.TempDir(), name)
+	tests.AssertNoError(t, os.WriteFile(path, []byte(content), 0666))
+
+	file.Path = path
+	if err := filesVersion.UpgradeVariableInFiles(UpgradeFilesMock{Files: []UpgradeFileMock{file}}, "1.1.0"); err != nil {
+		return path, err
+	}
+
+	result, err := os.ReadFile(path)
+	tests.AssertNoError(t, err)
+	return string(result), nil
+}
+
 func TestUpgradeVariableInFilesNoError(t *testing.T) {
 	f := setup(t)
 	filesVersion := f.newFiles()
 
-	filesToUpgrade := UpgradeFilesMock{Files: []UpgradeFileMock{{Path: "mock/setup_mock.py", VariableName: "__version__"}}}
+	filesToUpgrade := UpgradeFilesMock{Files: []UpgradeFileMock{{Path: copyMock(t, "setup_mock.py"), VariableName: "__version__"}}}
 	err := filesVersion.UpgradeVariableInFiles(filesToUpgrade, "1.0.1")
 	tests.AssertNoError(t, err)
 
@@ -85,6 +136,213 @@ func TestUpgradeVariableInFilesVariableNameNotFoundError(t *testing.T) {
 
 }
 
+func TestUpgradeVariableInFilesPackageJSONNoError(t *testing.T) {
+	content := "{\n  \"name\": \"app\",\n  \"version\": \"1.0.0\",\n  \"dependencies\": {\n    \"lib\": {\"version\": \"2.0.0\"}\n  }\n}\n"
+
+	result, err := upgradeVariableMock(t, "package.json", content, UpgradeFileMock{Type: constants.UpgradeTypePackageJSON})
+	tests.AssertNoError(t, err)
+	tests.AssertEqualValues(t, strings.Replace(content, "1.0.0", "1.1.0", 1), result)
+}
+
+func TestUpgradeVariableInFilesPackageLockNoError(t *testing.T) {
+	content := "{\n\t\"name\": \"app\",\n\t\"version\": \"1.0.0\",\n\t\"lockfileVersion\": 3,\n\t\"packages\": {\n\t\t\"\": {\n\t\t\t\"name\": \"app\",\n\t\t\t\"version\" : \"1.0.0\"\n\t\t},\n\t\t\"node_modules/lib\": {\n\t\t\t\"version\": \"1.0.0\"\n\t\t}\n\t}\n}"
+
+	result, err := upgradeVariableMock(t, "package-lock.json", content, UpgradeFileMock{Type: constants.UpgradeTypePackageJSON})
+	tests.AssertNoError(t, err)
+	tests.AssertEqualValues(t, strings.Replace(content, "1.0.0", "1.1.0", 2), result)
+}
+
+func TestUpgradeVariableInFilesPackageJSONVersionNotFoundError(t *testing.T) {
+	path, err := upgradeVariableMock(t, "package.json", `{"name": "app", "dependencies": {"version": "1.0.0"}}`, UpgradeFileMock{Type: constants.UpgradeTypePackageJSON})
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, fmt.Sprintf("error while upgrading version in file %s due to: version not found", path), err.Error())
+}
+
+func TestUpgradeVariableInFilesPyprojectNoError(t *testing.T) {
+	content := "[build-system]\r\nrequires = [\"setuptools\"]\r\n\r\n[project]\r\nname = \"app\"\r\ndescription = \"\"\"\r\nversion = \"0.0.1\"\r\n\"\"\"\r\n  version   =  '1.0.0'  # the release version\r\n\r\n[tool.app]\r\nversion = \"3.0.0\"\r\n"
+
+	result, err := upgradeVariableMock(t, "pyproject.toml", content, UpgradeFileMock{Type: constants.UpgradeTypePyproject})
+	tests.AssertNoError(t, err)
+	tests.AssertEqualValues(t, strings.Replace(content, "'1.0.0'", "'1.1.0'", 1), result)
+}
+
+func TestUpgradeVariableInFilesPoetryNoError(t *testing.T) {
+	content := "[[tool.poetry.source]]\nname = \"private\"\nversion = \"9.9.9\"\n\n[ tool . poetry ]\nname = \"app\"\nversion = \"1.0.0\"\n\n[tool.poetry.dependencies]\nversion = \"2.0.0\"\n"
+
+	result, err := upgradeVariableMock(t, "pyproject.toml", content, UpgradeFileMock{Type: constants.UpgradeTypePyproject})
+	tests.AssertNoError(t, err)
+	tests.AssertEqualValues(t, strings.Replace(content, "\"1.0.0\"", "\"1.1.0\"", 1), result)
+}
+
+func TestUpgradeVariableInFilesPyprojectDynamicPoetryNoError(t *testing.T) {
+	content := "[project]\nname = \"app\"\ndynamic = [\"version\"]\n\n[tool.poetry]\nversion = \"1.0.0\"\n"
+
+	result, err := upgradeVariableMock(t, "pyproject.toml", content, UpgradeFileMock{Type: constants.UpgradeTypePyproject})
+	tests.AssertNoError(t, err)
+	tests.AssertEqualValues(t, strings.Replace(content, "1.0.0", "1.1.0", 1), result)
+}
+
+func TestUpgradeVariableInFilesPyprojectDynamicError(t *testing.T) {
+	path, err := upgradeVariableMock(t, "pyproject.toml", "[project]\nname = \"app\"\ndynamic = [\n  \"readme\",\n  \"version\",\n]\n", UpgradeFileMock{Type: constants.UpgradeTypePyproject})
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, fmt.Sprintf("error while upgrading version in file %s due to: version is declared dynamic in [project] and must be upgraded by the build backend", path), err.Error())
+}
+
+func TestUpgradeVariableInFilesPyprojectVersionNotFoundError(t *testing.T) {
+	path, err := upgradeVariableMock(t, "pyproject.toml", "[tool.black]\nversion = \"1.0.0\"\n", UpgradeFileMock{Type: constants.UpgradeTypePyproject})
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, fmt.Sprintf("error while upgrading version in file %s due to: version not found in [project] or [tool.poetry]", path), err.Error())
+}
+
+func TestUpgradeVariableInFilesRegexNoError(t *testing.T) {
+	f := setup(t)
+	filesVersion := f.newFiles()
+
+	dir := t.TempDir()
+	content := "FROM golang:1.17\r\nARG VERSION=1.0.0\r\nARG GO_VERSION=1.17\r\nLABEL version=\"1.0.0\"\r\n"
+	for _, name := range []string{"api.Dockerfile", "worker.Dockerfile"} {
+		tests.AssertNoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0666))
+	}
+
+	filesToUpgrade := UpgradeFilesMock{Files: []UpgradeFileMock{{Path: filepath.Join(dir, "*.Dockerfile"), Type: constants.UpgradeTypeRegex, Pattern: `^ARG VERSION=(?P<version>[^\s]+)`, Matches: 1}}}
+	err := filesVersion.UpgradeVariableInFiles(filesToUpgrade, "1.1.0")
+	tests.AssertNoError(t, err)
+
+	for _, name := range []string{"api.Dockerfile", "worker.Dockerfile"} {
+		result, err := os.ReadFile(filepath.Join(dir, name))
+		tests.AssertNoError(t, err)
+		tests.AssertEqualValues(t, strings.Replace(content, "VERSION=1.0.0", "VERSION=1.1.0", 1), string(result))
+	}
+}
+
+func TestUpgradeVariableInFilesRegexMatchesError(t *testing.T) {
+	content := "api:\n  image: registry/app:1.0.0\nworker:\n  image: registry/app:1.0.0\n"
+
+	path, err := upgradeVariableMock(t, "values.yaml", content, UpgradeFileMock{Type: constants.UpgradeTypeRegex, Pattern: `image: registry/app:(?P<version>.*)`, Matches: 1})
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, fmt.Sprintf("error while upgrading version in file %s due to: pattern image: registry/app:(?P<version>.*) found 2 times, expected 1", path), err.Error())
+
+	path, err = upgradeVariableMock(t, "values.yaml", content, UpgradeFileMock{Type: constants.UpgradeTypeRegex, Pattern: `image: registry/app:(.*)`})
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, fmt.Sprintf("error while upgrading version in file %s due to: pattern image: registry/app:(.*) must have a group named version. I.e.: (?P<version>.*)", path), err.Error())
+
+	path, err = upgradeVariableMock(t, "values.yaml", content, UpgradeFileMock{Type: constants.UpgradeTypeRegex, Pattern: `tag: (?P<version>.*)`})
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, fmt.Sprintf("error while upgrading version in file %s due to: pattern tag: (?P<version>.*) not found", path), err.Error())
+}
+
+func TestUpgradeVariableInFilesRegexCRLFNoError(t *testing.T) {
+	content := "api:\r\n  image: registry/app:1.0.0\r\nworker:\r\n  image: registry/app:1.0.0\r\n"
+
+	result, err := upgradeVariableMock(t, "values.yaml", content, UpgradeFileMock{Type: constants.UpgradeTypeRegex, Pattern: `image: registry/app:(?P<version>.*)$`, Matches: 2})
+	tests.AssertNoError(t, err)
+	tests.AssertEqualValues(t, strings.ReplaceAll(content, "1.0.0", "1.1.0"), result)
+}
+
+func TestUpgradeVariableInFilesRegexOptionalGroupNoError(t *testing.T) {
+	content := "api:\n  image: registry/app:1.0.0\nworker:\n  image: registry/app:\n"
+
+	result, err := upgradeVariableMock(t, "values.yaml", content, UpgradeFileMock{Type: constants.UpgradeTypeRegex, Pattern: `image: registry/app:(?P<version>\S+)?`, Matches: 1})
+	tests.AssertNoError(t, err)
+	tests.AssertEqualValues(t, strings.Replace(content, "1.0.0", "1.1.0", 1), result)
+
+	path, err := upgradeVariableMock(t, "values.yaml", "image: registry/app:\n", UpgradeFileMock{Type: constants.UpgradeTypeRegex, Pattern: `image: registry/app:(?P<version>\S+)?`})
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, fmt.Sprintf("error while upgrading version in file %s due to: pattern image: registry/app:(?P<version>\\S+)? not found", path), err.Error())
+}
+
+func TestUpgradeVariableInFilesGlobNotFoundError(t *testing.T) {
+	f := setup(t)
+	filesVersion := f.newFiles()
+
+	filesToUpgrade := UpgradeFilesMock{Files: []UpgradeFileMock{{Path: "mock/*.toml", Type: constants.UpgradeTypeRegex, Pattern: `(?P<version>.*)`}}}
+	err := filesVersion.UpgradeVariableInFiles(filesToUpgrade, "1.1.0")
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, "no file matches path mock/*.toml", err.Error())
+}
+
+func TestUpgradeVariableInFilesYAMLNoError(t *testing.T) {
+	content := "# Helm chart\r\napiVersion: v2\r\nname: app\r\nversion: 1.0.0 # chart version\r\nappVersion: \"1.0.0\"\r\n"
+
+	result, err := upgradeVariableMock(t, "Chart.yaml", content, UpgradeFileMock{Type: constants.UpgradeTypeYAML, YAMLPaths: []string{"version", "appVersion"}})
+	tests.AssertNoError(t, err)
+	tests.AssertEqualValues(t, strings.ReplaceAll(content, "1.0.0", "1.1.0"), result)
+}
+
+func TestUpgradeVariableInFilesYAMLNestedPathNoError(t *testing.T) {
+	content := "descrição: serviço\nimage:\n    repository: registry/app\n    tag: '1.0.0'\n---\nspec:\n  containers:\n    - name: sidecar\n      image: proxy\n    - name: app\n      image: registry/app:1.0.0\n"
+
+	result, err := upgradeVariableMock(t, "values.yaml", content, UpgradeFileMock{Type: constants.UpgradeTypeYAML, YAMLPaths: []string{"image.tag"}})
+	tests.AssertNoError(t, err)
+	tests.AssertEqualValues(t, strings.Replace(content, "'1.0.0'", "'1.1.0'", 1), result)
+
+	result, err = upgradeVariableMock(t, "deployment.yaml", content, UpgradeFileMock{Type: constants.UpgradeTypeYAML, YAMLPaths: []string{"spec.containers[1].image"}})
+	tests.AssertNoError(t, err)
+	tests.AssertEqualValues(t, strings.Replace(content, "registry/app:1.0.0", "1.1.0", 1), result)
+}
+
+func TestUpgradeVariableInFilesYAMLError(t *testing.T) {
+	content := "image:\n  tag: 1.0.0\nnotes: |\n  1.0.0\n"
+
+	path, err := upgradeVariableMock(t, "values.yaml", content, UpgradeFileMock{Type: constants.UpgradeTypeYAML, YAMLPaths: []string{"image.version"}})
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, fmt.Sprintf("error while upgrading version in file %s due to: yaml path image.version not found", path), err.Error())
+
+	path, err = upgradeVariableMock(t, "values.yaml", content, UpgradeFileMock{Type: constants.UpgradeTypeYAML, YAMLPaths: []string{"image"}})
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, fmt.Sprintf("error while upgrading version in file %s due to: error while upgrading yaml path image due to: value is not a scalar", path), err.Error())
+
+	path, err = upgradeVariableMock(t, "values.yaml", content, UpgradeFileMock{Type: constants.UpgradeTypeYAML, YAMLPaths: []string{"notes"}})
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, fmt.Sprintf("error while upgrading version in file %s due to: error while upgrading yaml path notes due to: value 1.0.0\n must be a plain or quoted scalar", path), err.Error())
+
+	path, err = upgradeVariableMock(t, "values.yaml", content, UpgradeFileMock{Type: constants.UpgradeTypeYAML, YAMLPaths: []string{"image..tag"}})
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, fmt.Sprintf("error while upgrading version in file %s due to: invalid yaml path image..tag", path), err.Error())
+}
+
+func TestUpgradeVariableInFilesInvalidTypeError(t *testing.T) {
+	f := setup(t)
+	filesVersion := f.newFiles()
+
+	filesToUpgrade := UpgradeFilesMock{Files: []UpgradeFileMock{{Path: "mock/setup_mock.py", Type: "xml"}}}
+	err := filesVersion.UpgradeVariableInFiles(filesToUpgrade, "1.1.0")
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, "upgrade type xml of file mock/setup_mock.py is not supported", err.Error())
+}
+
+func TestUpgradeVariableInFilesKeepFormatNoError(t *testing.T) {
+	content := "if __version__ == '0.0.1':\r\n    pass\r\n\r\n    __version__  =  '1.0.0'  # the release version\r\n__version__ = \"2.0.0\"\r\n"
+
+	result, err := upgradeVariableMock(t, "setup.py", content, UpgradeFileMock{VariableName: "__version__"})
+	tests.AssertNoError(t, err)
+	tests.AssertEqualValues(t, strings.Replace(content, "'1.0.0'", "'1.1.0'", 1), result)
+}
+
+func TestUpgradeVariableInFilesGoConstNoError(t *testing.T) {
+	content := "package version\n\nconst (\n\tName    = \"app\"\n\tVersion = \"1.0.0\" // release version\n)\n\nvar Version2 string = `1.0.0`\n"
+
+	result, err := upgradeVariableMock(t, "version.go", content, UpgradeFileMock{VariableName: "Version"})
+	tests.AssertNoError(t, err)
+	tests.AssertEqualValues(t, strings.Replace(content, "\"1.0.0\"", "\"1.1.0\"", 1), result)
+
+	result, err = upgradeVariableMock(t, "version.go", content, UpgradeFileMock{VariableName: "Version2"})
+	tests.AssertNoError(t, err)
+	tests.AssertEqualValues(t, strings.Replace(content, "`1.0.0`", "`1.1.0`", 1), result)
+}
+
+func TestUpgradeVariableInFilesOccurrenceNoError(t *testing.T) {
+	content := "version := \"0.1.0\"\nexport VERSION=1.0.0 # image tag\nVERSION=get_version()\nexport VERSION=2.0.0\n"
+
+	result, err := upgradeVariableMock(t, "release.sh", content, UpgradeFileMock{VariableName: "VERSION", Occurrence: 2})
+	tests.AssertNoError(t, err)
+	tests.AssertEqualValues(t, strings.Replace(content, "2.0.0", "1.1.0", 1), result)
+
+	path, err := upgradeVariableMock(t, "release.sh", content, UpgradeFileMock{VariableName: "VERSION", Occurrence: 3})
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, fmt.Sprintf("error while getting file output data due to: occurrence 3 of variable name `VERSION` not found on file `%s`", path), err.Error())
+}
+
 func TestUpgradeVariableInFilesMarsahlError(t *testing.T) {
 	f := setup(t)
 	filesVersion := f.newFiles()
@@ -144,7 +402,7 @@ func TestUpgradeChangeLogNoError(t *testing.T) {
 		ChangeType:     "feat",
 	}
 
-	err := filesVersion.UpgradeChangeLog("mock/CHANGELOG_MOCK.md", "", changelog)
+	err := filesVersion.UpgradeChangeLog(copyMock(t, "CHANGELOG_MOCK.md"), "", changelog)
 	tests.AssertNoError(t, err)
 }
 
@@ -165,10 +423,45 @@ func TestUpgradeChangeLogLongMessageCutNoError(t *testing.T) {
 		ChangeType:     "feat",
 	}
 
-	err := filesVersion.UpgradeChangeLog("mock/CHANGELOG_MOCK.md", "", changelog)
+	err := filesVersion.UpgradeChangeLog(copyMock(t, "CHANGELOG_MOCK.md"), "", changelog)
 	tests.AssertNoError(t, err)
 }
 
+func TestUpgradeChangeLogMultipleChangesNoError(t *testing.T) {
+	f := setup(t)
+	f.versionControlHost = "gitlab.com"
+	f.groupName = "dataplatform"
+	f.projectName = "test"
+	filesVersion := f.newFiles()
+
+	changelogPath := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	tests.AssertNoError(t, os.WriteFile(changelogPath, []byte("# Changelog\n"), 0666))
+
+	changelog := ChangesInfoMock{
+		Hash:           "b25a9af78c30de0d03ca2ee6d18c66bbc4804395",
+		AuthorName:     "Administrator",
+		AuthorEmail:    "admin@git.com",
+		Message:        "fix: Fix a bug.",
+		CurrentVersion: "1.0.1",
+		NewVersion:     "1.1.0",
+		ChangeType:     "feat",
+		Changes: []ChangeInfoMock{
+			{Hash: "b25a9af78c30de0d03ca2ee6d18c66bbc4804395", AuthorName: "Administrator", AuthorEmail: "admin@git.com", Message: "fix: Fix a bug.", ChangeType: "fix"},
+			{Hash: "a13b7c2f9d1e0b3c8a7d6e5f4a3b2c1d0e9f8a7b", AuthorName: "John Doe", AuthorEmail: "john.doe@git.com", Message: "feat: Add a feature.", ChangeType: "feat"},
+		},
+	}
+
+	err := filesVersion.UpgradeChangeLog(changelogPath, "", changelog)
+	tests.AssertNoError(t, err)
+
+	content, err := os.ReadFile(changelogPath)
+	tests.AssertNoError(t, err)
+	tests.AssertEqualValues(t, true, strings.Contains(string(content), "## v1.1.0\n"+
+		"- fix - [b25a9af](https://gitlab.com/dataplatform/test/-/commit/b25a9af78c30de0d03ca2ee6d18c66bbc4804395): Fix a bug. (@admin)\n"+
+		"- feat - [a13b7c2](https://gitlab.com/dataplatform/test/-/commit/a13b7c2f9d1e0b3c8a7d6e5f4a3b2c1d0e9f8a7b): Add a feature. (@john.doe)\n"+
+		"---\n"))
+}
+
 func TestUpgradeChangeLogMarshalChangeLogInfoError(t *testing.T) {
 	f := setup(t)
 	f.versionControlHost = "gitlab.com"
@@ -344,3 +637,201 @@ func TestUpgradeChangeLogWriteFileError(t *testing.T) {
 	tests.AssertError(t, err)
 	tests.AssertEqualValues(t, "error while writing new version to changelog file due to: error while writing file mock/test/CHANGELOG_404.md due to: open mock/test/CHANGELOG_404.md: no such file or directory", err.Error())
 }
+
+func TestUpgradeVariableInFilesDryRunNoError(t *testing.T) {
+	f := setup(t)
+	filesVersion := f.newFiles()
+	filesVersion.SetDryRun(true)
+	var diffOutput bytes.Buffer
+	filesVersion.SetDiffOutput(&diffOutput)
+
+	before, err := os.ReadFile("mock/setup_mock.py")
+	tests.AssertNoError(t, err)
+
+	filesToUpgrade := UpgradeFilesMock{Files: []UpgradeFileMock{{Path: "mock/setup_mock.py", VariableName: "__version__"}}}
+	err = filesVersion.UpgradeVariableInFiles(filesToUpgrade, "9.9.9")
+	tests.AssertNoError(t, err)
+
+	after, err := os.ReadFile("mock/setup_mock.py")
+	tests.AssertNoError(t, err)
+	tests.AssertEqualValues(t, string(before), string(after))
+	tests.AssertEqualValues(t, files.UnifiedDiff("mock/setup_mock.py", string(before), strings.Replace(string(before), "1.0.1", "9.9.9", 1)), diffOutput.String())
+}
+
+func TestUpgradeChangeLogDryRunNoError(t *testing.T) {
+	f := setup(t)
+	f.versionControlHost = "gitlab.com"
+	f.groupName = "dataplatform"
+	f.projectName = "test"
+	filesVersion := f.newFiles()
+	filesVersion.SetDryRun(true)
+	var diffOutput bytes.Buffer
+	filesVersion.SetDiffOutput(&diffOutput)
+
+	changelog := ChangesInfoMock{
+		Hash:           "b25a9af78c30de0d03ca2ee6d18c66bbc4804395",
+		AuthorName:     "Administrator",
+		AuthorEmail:    "admin@git.com",
+		Message:        "feat(scope): This is a short message to write to CHANGELOG.md file.",
+		CurrentVersion: "1.0.1",
+		NewVersion:     "1.1.0",
+		ChangeType:     "feat",
+	}
+
+	before, err := os.ReadFile("mock/CHANGELOG_MOCK.md")
+	tests.AssertNoError(t, err)
+
+	err = filesVersion.UpgradeChangeLog("mock/CHANGELOG_MOCK.md", "", changelog)
+	tests.AssertNoError(t, err)
+
+	after, err := os.ReadFile("mock/CHANGELOG_MOCK.md")
+	tests.AssertNoError(t, err)
+	tests.AssertEqualValues(t, string(before), string(after))
+	tests.AssertTrue(t, strings.HasPrefix(diffOutput.String(), "--- a/mock/CHANGELOG_MOCK.md\n+++ b/mock/CHANGELOG_MOCK.md\n"))
+	tests.AssertTrue(t, strings.Contains(diffOutput.String(), "+## v1.1.0"))
+}
+
+func TestUpgradeChangeLogDryRunRelativePath(t *testing.T) {
+	f := setup(t)
+	f.repositoryRootPath = t.TempDir()
+	filesVersion := f.newFiles()
+	filesVersion.SetDryRun(true)
+	var diffOutput bytes.Buffer
+	filesVersion.SetDiffOutput(&diffOutput)
+
+	changelog := ChangesInfoMock{
+		Hash:           "b25a9af78c30de0d03ca2ee6d18c66bbc4804395",
+		AuthorName:     "Administrator",
+		AuthorEmail:    "admin@git.com",
+		Message:        "feat(scope): This is a short message to write to CHANGELOG.md file.",
+		CurrentVersion: "1.0.1",
+		NewVersion:     "1.1.0",
+		ChangeType:     "feat",
+	}
+
+	path := filepath.Join(f.repositoryRootPath, "docs", "CHANGELOG.md")
+	tests.AssertNoError(t, os.Mkdir(filepath.Dir(path), 0777))
+	tests.AssertNoError(t, os.WriteFile(path, []byte("\n"), 0666))
+
+	err := filesVersion.UpgradeChangeLog(path, "", changelog)
+	tests.AssertNoError(t, err)
+	tests.AssertTrue(t, strings.HasPrefix(diffOutput.String(), "--- a/docs/CHANGELOG.md\n+++ b/docs/CHANGELOG.md\n"))
+}
+
+func TestUnifiedDiffEqualContent(t *testing.T) {
+	tests.AssertEqualValues(t, "", files.UnifiedDiff("setup.py", "a\nb\n", "a\nb\n"))
+}
+
+func TestUnifiedDiffSuccess(t *testing.T) {
+	before := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n14\n15\n16\n"
+	after := "1\n2\n3\n4\n5\nsix\n7\n8\n9\n10\n11\n12\n13\n14\n15\n16\nseventeen\n"
+
+	expected := "--- a/file.txt\n+++ b/file.txt\n" +
+		"@@ -3,7 +3,7 @@\n 3\n 4\n 5\n-6\n+six\n 7\n 8\n 9\n" +
+		"@@ -14,3 +14,4 @@\n 14\n 15\n 16\n+seventeen\n"
+
+	tests.AssertEqualValues(t, expected, files.UnifiedDiff("file.txt", before, after))
+}
+
+func TestUnifiedDiffNewFileSuccess(t *testing.T) {
+	expected := "--- a/file.txt\n+++ b/file.txt\n@@ -0,0 +1,2 @@\n+a\n+b\n"
+	tests.AssertEqualValues(t, expected, files.UnifiedDiff("file.txt", "", "a\nb\n"))
+}
+
+func TestGetReleaseNotesNoError(t *testing.T) {
+	f := setup(t)
+	f.versionControlHost = "gitlab.com"
+	f.groupName = "dataplatform"
+	f.projectName = "test"
+	filesVersion := f.newFiles()
+
+	changelog := ChangesInfoMock{
+		Hash:           "b25a9af78c30de0d03ca2ee6d18c66bbc4804395",
+		AuthorName:     "Administrator",
+		AuthorEmail:    "admin@git.com",
+		Message:        "feat: Add a feature.",
+		CurrentVersion: "1.0.1",
+		NewVersion:     "1.1.0",
+		ChangeType:     "feat",
+	}
+
+	releaseNotes, err := filesVersion.GetReleaseNotes(changelog)
+	tests.AssertNoError(t, err)
+	tests.AssertEqualValues(t, "## v1.1.0\n"+
+		"- feat - [b25a9af](https://gitlab.com/dataplatform/test/-/commit/b25a9af78c30de0d03ca2ee6d18c66bbc4804395): Add a feature. (@admin)\n", releaseNotes)
+
+	changelog.NewVersion = ""
+	_, err = filesVersion.GetReleaseNotes(changelog)
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, "error validating changelog info due to: new version cannot be empty", err.Error())
+}
+
+func TestGetReleaseNotesLinkTemplates(t *testing.T) {
+	changelog := ChangesInfoMock{
+		Hash:           "b25a9af78c30de0d03ca2ee6d18c66bbc4804395",
+		AuthorName:     "Administrator",
+		AuthorEmail:    "admin@git.com",
+		Message:        "feat: Add a feature.",
+		CurrentVersion: "1.0.1",
+		NewVersion:     "1.1.0",
+		ChangeType:     "feat",
+		CurrentTag:     "v1.0.1",
+		NewTag:         "v1.1.0",
+	}
+
+	expectedByHost := map[string]string{
+		"gitlab.com": "- feat - [b25a9af](https://gitlab.com/group/subgroup/test/-/commit/b25a9af78c30de0d03ca2ee6d18c66bbc4804395): Add a feature. (@admin)\n\n" +
+			"**Full Changelog**: [v1.0.1...v1.1.0](https://gitlab.com/group/subgroup/test/-/compare/v1.0.1...v1.1.0)\n",
+		"github.com": "- feat - [b25a9af](https://github.com/group/subgroup/test/commit/b25a9af78c30de0d03ca2ee6d18c66bbc4804395): Add a feature. (@admin)\n\n" +
+			"**Full Changelog**: [v1.0.1...v1.1.0](https://github.com/group/subgroup/test/compare/v1.0.1...v1.1.0)\n",
+		"bitbucket.org": "- feat - [b25a9af](https://bitbucket.org/group/subgroup/test/commits/b25a9af78c30de0d03ca2ee6d18c66bbc4804395): Add a feature. (@admin)\n\n" +
+			"**Full Changelog**: [v1.0.1...v1.1.0](https://bitbucket.org/group/subgroup/test/branches/compare/v1.1.0%0Dv1.0.1)\n",
+	}
+
+	for host, expected := range expectedByHost {
+		f := setup(t)
+		f.versionControlHost = host
+		f.groupName = "group/subgroup"
+		f.projectName = "test"
+
+		releaseNotes, err := f.newFiles().GetReleaseNotes(changelog)
+		tests.AssertNoError(t, err)
+		tests.AssertEqualValues(t, "## v1.1.0\n"+expected, releaseNotes)
+	}
+}
+
+func TestSetLinkTemplates(t *testing.T) {
+	f := setup(t)
+	f.versionControlHost = "git.example.com"
+	f.groupName = "group"
+	f.projectName = "test"
+	filesVersion := f.newFiles()
+	filesVersion.SetLinkTemplates(files.LinkTemplates{Commit: "https://{host}/projects/{group}/repos/{project}/commits/{hash}"})
+
+	changelog := ChangesInfoMock{
+		Hash:           "b25a9af78c30de0d03ca2ee6d18c66bbc4804395",
+		AuthorName:     "Administrator",
+		AuthorEmail:    "admin@git.com",
+		Message:        "feat: Add a feature.",
+		CurrentVersion: "1.0.1",
+		NewVersion:     "1.1.0",
+		ChangeType:     "feat",
+		CurrentTag:     "v1.0.1",
+		NewTag:         "v1.1.0",
+	}
+
+	releaseNotes, err := filesVersion.GetReleaseNotes(changelog)
+	tests.AssertNoError(t, err)
+	tests.AssertEqualValues(t, "## v1.1.0\n- feat - [b25a9af](https://git.example.com/projects/group/repos/test/commits/b25a9af78c30de0d03ca2ee6d18c66bbc4804395): Add a feature. (@admin)\n", releaseNotes)
+}
+
+func TestDetectProvider(t *testing.T) {
+	tests.AssertEqualValues(t, constants.ProviderGitHub, files.DetectProvider("github.example.com"))
+	tests.AssertEqualValues(t, constants.ProviderBitbucket, files.DetectProvider("bitbucket.org"))
+	tests.AssertEqualValues(t, constants.ProviderGitLab, files.DetectProvider("gitlab.com"))
+	tests.AssertEqualValues(t, constants.ProviderGitLab, files.DetectProvider("git.example.com"))
+
+	_, err := files.GetProviderLinkTemplates("gitea")
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, "link provider gitea is not supported. Expected gitlab, github or bitbucket", err.Error())
+}
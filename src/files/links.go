@@ -0,0 +1,79 @@
+package files
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/NeowayLabs/semantic-release/src/constants"
+)
+
+// LinkTemplates renders the links of the changelog.
+// {host}, {group} and {project} are replaced by the repository coordinates, {hash} by the commit hash and
+// {from} and {to} by the tags compared. I.e.: https://{host}/{group}/{project}/-/commit/{hash}
+type LinkTemplates struct {
+	Commit  string
+	Compare string
+}
+
+var providerLinkTemplates = map[string]LinkTemplates{
+	constants.ProviderGitLab: {
+		Commit:  "https://{host}/{group}/{project}/-/commit/{hash}",
+		Compare: "https://{host}/{group}/{project}/-/compare/{from}...{to}",
+	},
+	constants.ProviderGitHub: {
+		Commit:  "https://{host}/{group}/{project}/commit/{hash}",
+		Compare: "https://{host}/{group}/{project}/compare/{from}...{to}",
+	},
+	constants.ProviderBitbucket: {
+		Commit:  "https://{host}/{group}/{project}/commits/{hash}",
+		Compare: "https://{host}/{group}/{project}/branches/compare/{to}%0D{from}",
+	},
+}
+
+// GetProviderLinkTemplates returns the link templates of a hosting provider: gitlab, github or bitbucket.
+func GetProviderLinkTemplates(provider string) (LinkTemplates, error) {
+	templates, found := providerLinkTemplates[provider]
+	if !found {
+		return LinkTemplates{}, fmt.Errorf("link provider %s is not supported. Expected gitlab, github or bitbucket", provider)
+	}
+	return templates, nil
+}
+
+// DetectProvider infers the hosting provider from the host name. I.e.: github.example.com is github
+// Hosts not named after a provider are considered self-hosted GitLab instances.
+func DetectProvider(host string) string {
+	host = strings.ToLower(host)
+	switch {
+	case strings.Contains(host, constants.ProviderGitHub):
+		return constants.ProviderGitHub
+	case strings.Contains(host, constants.ProviderBitbucket):
+		return constants.ProviderBitbucket
+	}
+	return constants.ProviderGitLab
+}
+
+// renderLink replaces the placeholders of a template. The group is kept as is, so nested GitLab subgroups
+// such as group/subgroup become part of the path.
+func (f *FileVersion) renderLink(template string, values ...string) string {
+	replacements := append([]string{
+		"{host}", f.versionConrolHost,
+		"{group}", strings.Trim(f.groupName, "/"),
+		"{project}", f.projectName,
+	}, values...)
+
+	return strings.NewReplacer(replacements...).Replace(template)
+}
+
+func (f *FileVersion) getCommitUrl(hash string) string {
+	return fmt.Sprintf("[%s](%s)", f.abbreviateHash(hash), f.renderLink(f.linkTemplates.Commit, constants.HashPlaceholder, hash))
+}
+
+// getCompareLink returns the link comparing the previous release to the new one, or an empty string when there is
+// no previous release to compare to.
+func (f *FileVersion) getCompareLink(fromTag, toTag string) string {
+	if fromTag == "" || toTag == "" || f.linkTemplates.Compare == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("[%s...%s](%s)", fromTag, toTag, f.renderLink(f.linkTemplates.Compare, constants.FromPlaceholder, fromTag, constants.ToPlaceholder, toTag))
+}
@@ -0,0 +1,152 @@
+package files
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// packageVersionPaths are the version values of package.json and package-lock.json.
+// The root package entry only exists in lockfiles from version 2 on.
+var packageVersionPaths = [][]string{
+	{"version"},
+	{"packages", "", "version"},
+}
+
+// jsonStringOffset is the position of a string value in a JSON document, quotes included.
+type jsonStringOffset struct {
+	start int
+	end   int
+}
+
+// skipJSONValue consumes the value following the last token read by the decoder.
+func skipJSONValue(decoder *json.Decoder) error {
+	depth := 0
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// findJSONString returns the offset of the string value found by following the keys of path from the root object.
+// It returns false when any of the keys does not exist.
+func findJSONString(content []byte, path []string) (jsonStringOffset, bool, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+
+	for depth, key := range path {
+		token, err := decoder.Token()
+		if err != nil {
+			return jsonStringOffset{}, false, err
+		}
+
+		if token != json.Delim('{') {
+			return jsonStringOffset{}, false, nil
+		}
+
+		found := false
+		for decoder.More() {
+			token, err := decoder.Token()
+			if err != nil {
+				return jsonStringOffset{}, false, err
+			}
+
+			if token != key {
+				if err := skipJSONValue(decoder); err != nil {
+					return jsonStringOffset{}, false, err
+				}
+				continue
+			}
+
+			found = true
+			break
+		}
+
+		if !found {
+			return jsonStringOffset{}, false, nil
+		}
+
+		if depth < len(path)-1 {
+			continue
+		}
+
+		// the decoder stops right after the key, so the value starts after the colon and the white space
+		start := int(decoder.InputOffset())
+		for start < len(content) && bytes.IndexByte([]byte(" \t\r\n:"), content[start]) >= 0 {
+			start++
+		}
+
+		token, err = decoder.Token()
+		if err != nil {
+			return jsonStringOffset{}, false, err
+		}
+
+		if _, isString := token.(string); !isString {
+			return jsonStringOffset{}, false, fmt.Errorf("value %v is not a string", token)
+		}
+
+		return jsonStringOffset{start: start, end: int(decoder.InputOffset())}, true, nil
+	}
+
+	return jsonStringOffset{}, false, nil
+}
+
+// upgradePackageJSON replaces the version of a package.json or package-lock.json content.
+// Only the version values change, so the order of the keys, the indentation and the trailing new line are kept.
+// I.e.:
+//
+//	From: "version": "1.0.0",
+//	To:   "version": "1.0.1",
+func upgradePackageJSON(content []byte, newVersion string) ([]byte, error) {
+	if !json.Valid(content) {
+		return nil, errors.New("invalid json content")
+	}
+
+	var offsets []jsonStringOffset
+	for _, path := range packageVersionPaths {
+		offset, found, err := findJSONString(content, path)
+		if err != nil {
+			return nil, fmt.Errorf("error while reading %v due to: %w", path, err)
+		}
+
+		if found {
+			offsets = append(offsets, offset)
+		}
+	}
+
+	if len(offsets) == 0 {
+		return nil, errors.New("version not found")
+	}
+
+	version, err := json.Marshal(newVersion)
+	if err != nil {
+		return nil, fmt.Errorf("error while marshalling version due to: %w", err)
+	}
+
+	// the values are replaced from the last one, so the offsets before it stay valid
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i].start > offsets[j].start })
+
+	output := content
+	for _, offset := range offsets {
+		replaced := make([]byte, 0, len(output)+len(version))
+		replaced = append(replaced, output[:offset.start]...)
+		replaced = append(replaced, version...)
+		output = append(replaced, output[offset.end:]...)
+	}
+
+	return output, nil
+}
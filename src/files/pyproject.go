@@ -0,0 +1,114 @@
+package files
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	pyprojectTable = "project"
+	poetryTable    = "tool.poetry"
+)
+
+var (
+	tomlTablePattern   = regexp.MustCompile(`^\s*\[\s*([^\[\]]+?)\s*\]\s*(#.*)?$`)
+	tomlVersionPattern = regexp.MustCompile(`^(\s*version\s*=\s*)("[^"]*"|'[^']*')`)
+	tomlDynamicPattern = regexp.MustCompile(`^\s*dynamic\s*=`)
+	tomlDynamicVersion = regexp.MustCompile(`["']version["']`)
+)
+
+// pyprojectVersion is the line of a version key in a pyproject.toml table.
+type pyprojectVersion struct {
+	line  int
+	found bool
+}
+
+// tomlTableName normalizes a table header, so [ tool . poetry ] is tool.poetry
+func tomlTableName(header string) string {
+	parts := strings.Split(header, ".")
+	for i, part := range parts {
+		parts[i] = strings.TrimSpace(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// upgradePyproject replaces the version of the [project] table, as defined by PEP 621, or of the [tool.poetry] table.
+// Only the version literal changes, so comments, quotes and the rest of the document are kept.
+// I.e.:
+//
+//	From: version = "1.0.0"  # managed by semantic-release
+//	To:   version = "1.0.1"  # managed by semantic-release
+//
+// It fails when the [project] table declares the version as dynamic and there is no [tool.poetry] version to upgrade.
+func upgradePyproject(content []byte, newVersion string) ([]byte, error) {
+	lines := strings.SplitAfter(string(content), "\n")
+
+	versions := map[string]*pyprojectVersion{pyprojectTable: {}, poetryTable: {}}
+	table, multilineString, dynamic, inDynamic := "", "", false, false
+
+	for i, line := range lines {
+		// multi-line strings, such as a long description, may contain anything that looks like a table or a key
+		if multilineString != "" {
+			if strings.Count(line, multilineString)%2 == 1 {
+				multilineString = ""
+			}
+			continue
+		}
+
+		if inDynamic {
+			dynamic = dynamic || tomlDynamicVersion.MatchString(line)
+			inDynamic = !strings.Contains(line, "]")
+			continue
+		}
+
+		// entries of arrays of tables, such as [[tool.poetry.source]], are not the tables holding the version
+		if strings.HasPrefix(strings.TrimSpace(line), "[[") {
+			table = strings.TrimSpace(line)
+			continue
+		}
+
+		if match := tomlTablePattern.FindStringSubmatch(line); match != nil {
+			table = tomlTableName(match[1])
+			continue
+		}
+
+		if version, isVersionTable := versions[table]; isVersionTable && !version.found && tomlVersionPattern.MatchString(line) {
+			version.line, version.found = i, true
+		}
+
+		if table == pyprojectTable && tomlDynamicPattern.MatchString(line) {
+			dynamic = tomlDynamicVersion.MatchString(line)
+			inDynamic = !strings.Contains(line, "]")
+		}
+
+		for _, delimiter := range []string{`"""`, `'''`} {
+			if strings.Count(line, delimiter)%2 == 1 {
+				multilineString = delimiter
+				break
+			}
+		}
+	}
+
+	version := versions[pyprojectTable]
+	if dynamic || !version.found {
+		version = versions[poetryTable]
+	}
+
+	if !version.found {
+		if dynamic {
+			return nil, errors.New("version is declared dynamic in [project] and must be upgraded by the build backend")
+		}
+		return nil, errors.New("version not found in [project] or [tool.poetry]")
+	}
+
+	line := lines[version.line]
+	match := tomlVersionPattern.FindStringSubmatchIndex(line)
+	literal := line[match[4]:match[5]]
+	quote := literal[:1]
+
+	lines[version.line] = fmt.Sprintf("%s%s%s%s%s", line[:match[4]], quote, newVersion, quote, line[match[5]:])
+
+	return []byte(strings.Join(lines, "")), nil
+}
@@ -0,0 +1,99 @@
+package files
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/NeowayLabs/semantic-release/src/constants"
+)
+
+// compileVersionPattern compiles a regular expression with a group named version. I.e.: image: registry/app:(?P<version>.*)
+// The expression runs in multi-line mode, so ^ and $ match the beginning and the end of every line.
+func compileVersionPattern(pattern string) (*regexp.Regexp, error) {
+	expression, err := regexp.Compile("(?m)" + pattern)
+	if err != nil {
+		return nil, fmt.Errorf("error while compiling pattern %s due to: %w", pattern, err)
+	}
+
+	if expression.SubexpIndex(constants.VersionGroup) < 0 {
+		return nil, fmt.Errorf("pattern %s must have a group named %s. I.e.: (?P<%s>.*)", pattern, constants.VersionGroup, constants.VersionGroup)
+	}
+
+	return expression, nil
+}
+
+// upgradeRegex replaces the version group of every match of pattern, keeping the rest of the content.
+// When expectedMatches is not zero, the content must have exactly that many versions replaced, otherwise at least one.
+// I.e.: with the pattern ^ARG VERSION=(?P<version>\S+)
+//
+//	From: ARG VERSION=1.0.0
+//	To:   ARG VERSION=1.0.1
+func upgradeRegex(content []byte, pattern string, expectedMatches int, newVersion string) ([]byte, error) {
+	expression, err := compileVersionPattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	group := expression.SubexpIndex(constants.VersionGroup)
+
+	var output []byte
+	last, replaced := 0, 0
+	for _, match := range expression.FindAllSubmatchIndex(content, -1) {
+		start, end := match[2*group], match[2*group+1]
+		// an optional version group may not take part in the match
+		if start < 0 {
+			continue
+		}
+
+		// $ matches before \n only, so a group such as (?P<version>.*) captures the \r of CRLF line endings
+		if end > start && content[end-1] == '\r' {
+			end--
+		}
+
+		output = append(output, content[last:start]...)
+		output = append(output, newVersion...)
+		last = end
+		replaced++
+	}
+
+	if replaced == 0 {
+		return nil, fmt.Errorf("pattern %s not found", pattern)
+	}
+
+	if expectedMatches > 0 && replaced != expectedMatches {
+		return nil, fmt.Errorf("pattern %s found %d times, expected %d", pattern, replaced, expectedMatches)
+	}
+
+	return append(output, content[last:]...), nil
+}
+
+// expandGlobs replaces the files whose path is a glob pattern by the files matching it. I.e.: charts/*/Chart.yaml
+// The matched files are upgraded in place.
+func expandGlobs(upgradeFiles []UpgradeFile) ([]UpgradeFile, error) {
+	var expanded []UpgradeFile
+	for _, file := range upgradeFiles {
+		if !strings.ContainsAny(file.Path, "*?[") {
+			expanded = append(expanded, file)
+			continue
+		}
+
+		paths, err := filepath.Glob(file.Path)
+		if err != nil {
+			return nil, fmt.Errorf("error while expanding path %s due to: %w", file.Path, err)
+		}
+
+		if len(paths) == 0 {
+			return nil, errors.New("no file matches path " + file.Path)
+		}
+
+		for _, path := range paths {
+			file.Path, file.DestinationPath = path, ""
+			expanded = append(expanded, file)
+		}
+	}
+
+	return expanded, nil
+}
@@ -0,0 +1,68 @@
+package files
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// variableKeywords may precede the name of a variable. I.e.: const Version = "1.0.0" or export VERSION="1.0.0"
+const variableKeywords = `(?:(?:export|const|var|val|let|final|static|public|private|readonly)[ \t]+)*`
+
+// variableTypeAnnotation may follow the name of a variable. I.e.: var Version string = "1.0.0" or __version__: str = "1.0.0"
+const variableTypeAnnotation = `(?:[ \t]*:[ \t]*[\w.]+|[ \t]+[\w.]+)?`
+
+// variableLiteral is the value assigned, with its quotes captured apart so they are kept.
+const variableLiteral = `(?:"(?P<double>[^"\r\n]*)"|'(?P<single>[^'\r\n]*)'|` + "`(?P<backtick>[^`\\r\\n]*)`" + `|(?P<bare>[\w.+-]+)(?:[ \t]*(?:#|//|;)|[ \t]*\r?$))`
+
+// variablePattern matches the assignments of variableName at the beginning of a line with = or :=.
+// Comparisons such as __version__ == "1.0.0" are not assignments, since the value must follow the first =.
+func variablePattern(variableName string) *regexp.Regexp {
+	return regexp.MustCompile(`(?m)^[ \t]*` + variableKeywords + regexp.QuoteMeta(variableName) + variableTypeAnnotation + `[ \t]*:?=[ \t]*` + variableLiteral)
+}
+
+// upgradeVariableContent replaces the value assigned to variableName in its occurrence, counted from 1.
+// Only the value changes, so the indentation, the quotes, inline comments and line endings are kept.
+// It returns false when the variable has less assignments than occurrence.
+// I.e.:
+//
+//	From:	const Version = "1.0.0" // release version
+//	To:	const Version = "1.0.1" // release version
+func upgradeVariableContent(content []byte, variableName string, occurrence int, newVersion string) ([]byte, bool) {
+	if occurrence < 1 {
+		occurrence = 1
+	}
+
+	pattern := variablePattern(variableName)
+	matches := pattern.FindAllSubmatchIndex(content, occurrence)
+	if len(matches) < occurrence {
+		return nil, false
+	}
+
+	match := matches[occurrence-1]
+	for group := 1; group < len(match)/2; group++ {
+		start, end := match[2*group], match[2*group+1]
+		if start < 0 {
+			continue
+		}
+
+		output := make([]byte, 0, len(content)+len(newVersion))
+		output = append(output, content[:start]...)
+		output = append(output, newVersion...)
+		return append(output, content[end:]...), true
+	}
+
+	return nil, false
+}
+
+// getFileOutputContent returns the content of the file with its variable upgraded.
+func (f *FileVersion) getFileOutputContent(content []byte, file UpgradeFile, newVersion string) ([]byte, error) {
+	outputData, found := upgradeVariableContent(content, file.VariableName, file.Occurrence, newVersion)
+	if !found {
+		if file.Occurrence > 1 {
+			return nil, fmt.Errorf("occurrence %d of variable name `%s` not found on file `%s`", file.Occurrence, file.VariableName, file.Path)
+		}
+		return nil, fmt.Errorf("variable name `%s` not found on file `%s`", file.VariableName, file.Path)
+	}
+
+	return outputData, nil
+}
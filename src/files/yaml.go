@@ -0,0 +1,209 @@
+package files
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlPathSegmentPattern matches a segment of a YAML path: a key followed by optional sequence indexes. I.e.: containers[0]
+var yamlPathSegmentPattern = regexp.MustCompile(`^([^\[\]]*)((?:\[\d+\])*)$`)
+
+// yamlScalarOffset is the position of a scalar value in a YAML document, quotes excluded.
+type yamlScalarOffset struct {
+	start int
+	end   int
+}
+
+// yamlPathStep is a key of a mapping or, when key is empty, an index of a sequence.
+type yamlPathStep struct {
+	key   string
+	index int
+}
+
+// parseYAMLPath splits a path such as spec.containers[0].image into the keys and indexes followed from the root.
+func parseYAMLPath(path string) ([]yamlPathStep, error) {
+	var steps []yamlPathStep
+	for _, segment := range strings.Split(path, ".") {
+		match := yamlPathSegmentPattern.FindStringSubmatch(segment)
+		if match == nil || (match[1] == "" && match[2] == "") {
+			return nil, fmt.Errorf("invalid yaml path %s", path)
+		}
+
+		if match[1] != "" {
+			steps = append(steps, yamlPathStep{key: match[1]})
+		}
+
+		for _, index := range strings.Split(strings.Trim(match[2], "[]"), "][") {
+			if index == "" {
+				continue
+			}
+			position, _ := strconv.Atoi(index)
+			steps = append(steps, yamlPathStep{index: position})
+		}
+	}
+
+	return steps, nil
+}
+
+// findYAMLNode follows the steps from node, returning nil when any of them does not exist.
+func findYAMLNode(node *yaml.Node, steps []yamlPathStep) *yaml.Node {
+	for _, step := range steps {
+		for node.Kind == yaml.AliasNode {
+			node = node.Alias
+		}
+
+		switch {
+		case step.key != "" && node.Kind == yaml.MappingNode:
+			var value *yaml.Node
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == step.key {
+					value = node.Content[i+1]
+				}
+			}
+			if value == nil {
+				return nil
+			}
+			node = value
+		case step.key == "" && node.Kind == yaml.SequenceNode && step.index < len(node.Content):
+			node = node.Content[step.index]
+		default:
+			return nil
+		}
+	}
+
+	for node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	return node
+}
+
+// lineOffsets returns the offset where every line of content starts.
+func lineOffsets(content []byte) []int {
+	offsets := []int{0}
+	for i, character := range content {
+		if character == '\n' {
+			offsets = append(offsets, i+1)
+		}
+	}
+	return offsets
+}
+
+// scalarOffset locates the value of a scalar node in content, excluding its quotes.
+func scalarOffset(content []byte, lines []int, node *yaml.Node) (yamlScalarOffset, error) {
+	if node.Kind != yaml.ScalarNode {
+		return yamlScalarOffset{}, errors.New("value is not a scalar")
+	}
+
+	if node.Style&(yaml.LiteralStyle|yaml.FoldedStyle|yaml.TaggedStyle) != 0 {
+		return yamlScalarOffset{}, fmt.Errorf("value %s must be a plain or quoted scalar", node.Value)
+	}
+
+	if node.Line < 1 || node.Line > len(lines) {
+		return yamlScalarOffset{}, fmt.Errorf("value %s is out of the content", node.Value)
+	}
+
+	// the column of the node counts characters, not bytes
+	start := lines[node.Line-1]
+	for column := 1; column < node.Column && start < len(content); column++ {
+		_, size := utf8.DecodeRune(content[start:])
+		start += size
+	}
+
+	if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+		start++
+	}
+
+	end := start + len(node.Value)
+	if end > len(content) || !bytes.Equal(content[start:end], []byte(node.Value)) {
+		return yamlScalarOffset{}, fmt.Errorf("value %s must be written in a single line without escapes", node.Value)
+	}
+
+	return yamlScalarOffset{start: start, end: end}, nil
+}
+
+// upgradeYAML replaces the values found by following paths in every document of content.
+// Only the values change, so comments, quotes, indentation and the rest of the documents are kept.
+// I.e.: with the paths version and appVersion of a Chart.yaml
+//
+//	From: version: 1.0.0 # chart version
+//	To:   version: 1.0.1 # chart version
+func upgradeYAML(content []byte, paths []string, newVersion string) ([]byte, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("yaml paths cannot be empty")
+	}
+
+	var documents []*yaml.Node
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var document yaml.Node
+		if err := decoder.Decode(&document); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("error while decoding yaml due to: %w", err)
+		}
+		documents = append(documents, &document)
+	}
+
+	lines := lineOffsets(content)
+
+	var offsets []yamlScalarOffset
+	for _, path := range paths {
+		steps, err := parseYAMLPath(path)
+		if err != nil {
+			return nil, err
+		}
+
+		found := false
+		for _, document := range documents {
+			if len(document.Content) == 0 {
+				continue
+			}
+
+			node := findYAMLNode(document.Content[0], steps)
+			if node == nil {
+				continue
+			}
+
+			offset, err := scalarOffset(content, lines, node)
+			if err != nil {
+				return nil, fmt.Errorf("error while upgrading yaml path %s due to: %w", path, err)
+			}
+
+			offsets = append(offsets, offset)
+			found = true
+		}
+
+		if !found {
+			return nil, fmt.Errorf("yaml path %s not found", path)
+		}
+	}
+
+	// replacing from the end keeps the offsets not replaced yet valid
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i].start > offsets[j].start })
+
+	output := content
+	for i, offset := range offsets {
+		// aliases and repeated paths may point to the same value
+		if i > 0 && offset == offsets[i-1] {
+			continue
+		}
+
+		replaced := make([]byte, 0, len(output)+len(newVersion))
+		replaced = append(replaced, output[:offset.start]...)
+		replaced = append(replaced, newVersion...)
+		output = append(replaced, output[offset.end:]...)
+	}
+
+	return output, nil
+}
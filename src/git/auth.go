@@ -0,0 +1,173 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+)
+
+const (
+	defaultSSHUser     = "git"
+	defaultTokenUser   = "oauth2"
+	gitLabJobTokenUser = "gitlab-ci-token"
+)
+
+// Auth provides the credentials used to clone and push.
+type Auth interface {
+	// Validate checks whether the credentials are complete.
+	Validate() error
+	// Method returns the authentication method of the transport.
+	Method() (transport.AuthMethod, error)
+}
+
+// BasicAuth authenticates over HTTPS with a username and a password or access token.
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+func (a *BasicAuth) Validate() error {
+	if a.Username == "" {
+		return errors.New("username cannot be empty")
+	}
+
+	if a.Password == "" {
+		return errors.New("password cannot be empty")
+	}
+
+	return nil
+}
+
+func (a *BasicAuth) Method() (transport.AuthMethod, error) {
+	if a.Username == "" || a.Password == "" {
+		return nil, errors.New("username and password are required to push changes")
+	}
+
+	return &http.BasicAuth{
+		Username: a.Username,
+		Password: a.Password,
+	}, nil
+}
+
+// TokenAuth authenticates over HTTPS with an access token.
+type TokenAuth struct {
+	// Username is optional. Servers such as GitLab and GitHub accept any username along with a token.
+	Username string
+	Token    string
+	// Bearer sends the token in the Authorization: Bearer header instead of using basic auth.
+	Bearer bool
+}
+
+// NewJobTokenAuth returns the credentials of a GitLab CI job token. I.e.: ${CI_JOB_TOKEN}
+func NewJobTokenAuth(token string) *TokenAuth {
+	return &TokenAuth{Username: gitLabJobTokenUser, Token: token}
+}
+
+func (a *TokenAuth) Validate() error {
+	if a.Token == "" {
+		return errors.New("token cannot be empty")
+	}
+
+	return nil
+}
+
+// IsJobToken returns true for the credentials of a GitLab CI job token. See NewJobTokenAuth
+func (a *TokenAuth) IsJobToken() bool {
+	return a.Username == gitLabJobTokenUser
+}
+
+func (a *TokenAuth) user() string {
+	if a.Username == "" {
+		return defaultTokenUser
+	}
+	return a.Username
+}
+
+func (a *TokenAuth) Method() (transport.AuthMethod, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+
+	if a.Bearer {
+		return &http.TokenAuth{Token: a.Token}, nil
+	}
+
+	return &http.BasicAuth{
+		Username: a.user(),
+		Password: a.Token,
+	}, nil
+}
+
+// SSHAuth authenticates over SSH with a private key file or the keys of the running ssh-agent.
+// The host key is always verified against the known_hosts file.
+type SSHAuth struct {
+	// User defaults to git.
+	User string
+	// PrivateKeyPath is the private key file. It is ignored when UseAgent is true.
+	PrivateKeyPath string
+	// Passphrase decrypts the private key, if it is encrypted.
+	Passphrase string
+	// UseAgent authenticates with the keys of the agent listening on SSH_AUTH_SOCK.
+	UseAgent bool
+	// KnownHostsPath defaults to the files in SSH_KNOWN_HOSTS or to ~/.ssh/known_hosts and /etc/ssh/ssh_known_hosts.
+	KnownHostsPath string
+}
+
+func (a *SSHAuth) Validate() error {
+	if !a.UseAgent && a.PrivateKeyPath == "" {
+		return errors.New("ssh private key or ssh-agent is required")
+	}
+
+	return nil
+}
+
+func (a *SSHAuth) user() string {
+	if a.User == "" {
+		return defaultSSHUser
+	}
+	return a.User
+}
+
+func (a *SSHAuth) hostKeyCallback() (ssh.HostKeyCallbackHelper, error) {
+	var knownHostsFiles []string
+	if a.KnownHostsPath != "" {
+		knownHostsFiles = append(knownHostsFiles, a.KnownHostsPath)
+	}
+
+	callback, err := ssh.NewKnownHostsCallback(knownHostsFiles...)
+	if err != nil {
+		return ssh.HostKeyCallbackHelper{}, fmt.Errorf("error while loading known hosts due to: %w", err)
+	}
+
+	return ssh.HostKeyCallbackHelper{HostKeyCallback: callback}, nil
+}
+
+func (a *SSHAuth) Method() (transport.AuthMethod, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+
+	hostKeyCallback, err := a.hostKeyCallback()
+	if err != nil {
+		return nil, err
+	}
+
+	if a.UseAgent {
+		auth, err := ssh.NewSSHAgentAuth(a.user())
+		if err != nil {
+			return nil, fmt.Errorf("error while connecting to ssh-agent due to: %w", err)
+		}
+		auth.HostKeyCallbackHelper = hostKeyCallback
+		return auth, nil
+	}
+
+	auth, err := ssh.NewPublicKeysFromFile(a.user(), a.PrivateKeyPath, a.Passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading ssh private key due to: %w", err)
+	}
+	auth.HostKeyCallbackHelper = hostKeyCallback
+	return auth, nil
+}
@@ -0,0 +1,162 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// TokenFromFile reads an access token from path, ignoring surrounding white space.
+// I.e.: a secret mounted by the CI platform or by Kubernetes.
+func TokenFromFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("error while reading token file due to: %w", err)
+	}
+
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", fmt.Errorf("token file %s is empty", path)
+	}
+
+	return token, nil
+}
+
+// newAuth returns basic auth credentials, or token credentials when the username is unknown.
+func newAuth(username, password string) Auth {
+	if username == "" {
+		return &TokenAuth{Token: password}
+	}
+	return &BasicAuth{Username: username, Password: password}
+}
+
+func defaultNetrcPath() string {
+	if path := os.Getenv("NETRC"); path != "" {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".netrc"
+	}
+	return filepath.Join(home, ".netrc")
+}
+
+// NetrcAuth reads the credentials of host from the netrc file at path, falling back to its default entry.
+// When path is empty, the file in NETRC or ~/.netrc is read. The port of host is ignored, since netrc machines have none.
+// See https://www.gnu.org/software/inetutils/manual/html_node/The-_002enetrc-file.html
+func NetrcAuth(path, host string) (Auth, error) {
+	if path == "" {
+		path = defaultNetrcPath()
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading netrc file due to: %w", err)
+	}
+
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+
+	var login, password string
+	found, isDefault, inMacro := false, false, false
+	var machine string
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// a macro definition lasts until the next empty line and may contain any token
+		if inMacro {
+			inMacro = strings.TrimSpace(line) != ""
+			continue
+		}
+
+		fields := strings.Fields(line)
+		for i := 0; i < len(fields); i++ {
+			next := ""
+			if i+1 < len(fields) {
+				next = fields[i+1]
+			}
+
+			switch fields[i] {
+			case "machine":
+				if found {
+					return newAuth(login, password), nil
+				}
+				machine, isDefault = next, false
+				login, password = "", ""
+				i++
+			case "default":
+				if found {
+					return newAuth(login, password), nil
+				}
+				machine, isDefault = "", true
+				login, password = "", ""
+			case "login":
+				login = next
+				i++
+			case "password":
+				password = next
+				i++
+			case "account":
+				i++
+			case "macdef":
+				inMacro = true
+				i = len(fields)
+			}
+
+			found = (machine == host || isDefault) && password != ""
+		}
+	}
+
+	if found {
+		return newAuth(login, password), nil
+	}
+
+	return nil, fmt.Errorf("no credentials found for %s in netrc file %s", host, path)
+}
+
+// CredentialHelperAuth asks the git credential helpers configured in the environment for the credentials of host.
+// It behaves as `git credential fill` without prompting for anything missing.
+// See https://git-scm.com/docs/git-credential
+func CredentialHelperAuth(host string) (Auth, error) {
+	cmd := exec.Command("git", "credential", "fill")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("protocol=https\nhost=%s\n\n", host))
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GIT_ASKPASS=", "SSH_ASKPASS=")
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("error while running git credential fill due to: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var username, password string
+	for _, line := range strings.Split(string(output), "\n") {
+		keyValue := strings.SplitN(line, "=", 2)
+		if len(keyValue) != 2 {
+			continue
+		}
+
+		switch keyValue[0] {
+		case "username":
+			username = keyValue[1]
+		case "password":
+			password = keyValue[1]
+		}
+	}
+
+	if password == "" {
+		return nil, fmt.Errorf("git credential helper returned no password for %s", host)
+	}
+
+	return newAuth(username, password), nil
+}
@@ -3,12 +3,16 @@ package git_test
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/NeowayLabs/semantic-release/src/git"
 	"github.com/NeowayLabs/semantic-release/src/log"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 )
@@ -158,3 +162,43 @@ func (f *fixture) cleanLocalRepo(t *testing.T) {
 func (f *fixture) newGitService() (*git.GitVersioning, error) {
 	return git.NewMock(f.log, printElapsedTimeMock, f.gitLabVersioning.url, f.gitLabVersioning.username, f.gitLabVersioning.password, f.gitLabVersioning.destinationDirectory, f.gitFunctions)
 }
+
+// newLocalRepository creates a repository in a temporary directory with one commit per message.
+func newLocalRepository(t *testing.T, remoteURL string, messages ...string) string {
+	t.Helper()
+	path := t.TempDir()
+
+	repo, err := gogit.PlainInit(path, false)
+	if err != nil {
+		t.Fatalf("error while initializing local repository due to: %s", err.Error())
+	}
+
+	if remoteURL != "" {
+		if _, err := repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{remoteURL}}); err != nil {
+			t.Fatalf("error while creating remote due to: %s", err.Error())
+		}
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("error while getting worktree due to: %s", err.Error())
+	}
+
+	for i, message := range messages {
+		fileName := fmt.Sprintf("file%d.txt", i)
+		if err := os.WriteFile(filepath.Join(path, fileName), []byte(message), 0666); err != nil {
+			t.Fatalf("error while writing file due to: %s", err.Error())
+		}
+
+		if _, err := worktree.Add(fileName); err != nil {
+			t.Fatalf("error while adding file due to: %s", err.Error())
+		}
+
+		signature := &object.Signature{Name: "John Doe", Email: "john@doe.com", When: time.Now().Add(time.Duration(i) * time.Second)}
+		if _, err := worktree.Commit(message, &gogit.CommitOptions{Author: signature, Committer: signature}); err != nil {
+			t.Fatalf("error while committing due to: %s", err.Error())
+		}
+	}
+
+	return path
+}
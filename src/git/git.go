@@ -4,26 +4,20 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/NeowayLabs/semantic-release/src/constants"
+	"github.com/NeowayLabs/semantic-release/src/semver"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
-	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 )
 
-const (
-	colorCyan   = "\033[36m"
-	colorYellow = "\033[33m"
-	colorReset  = "\033[0m"
-	colorGreen  = "\033[32m"
-)
-
-var pattern = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)$`)
+const defaultTagFormat = constants.VersionPlaceholder
 
 type Logger interface {
 	Info(s string, args ...interface{})
@@ -55,17 +49,23 @@ type GitVersioning struct {
 	printElapsedTime           ElapsedTime
 	url                        string
 	destinationDirectory       string
-	username                   string
-	password                   string
+	credentials                Auth
 	repo                       *git.Repository
 	branchHead                 *plumbing.Reference
 	commitHistory              []*object.Commit
 	commitHistoryCurrentBranch []*object.Commit
 	commitHistoryDiff          []*object.Commit
 	tagsList                   []object.Tag
+	commitsSinceLastRelease    []*object.Commit
 	mostRecentCommit           CommitInfo
 	mostRecentTag              string
 	branchName                 string
+	tagFormat                  string
+	matchLegacyTags            bool
+	ignorePreReleaseTags       bool
+	maintenanceRange           *semver.MaintenanceRange
+	cloned                     bool
+	lintSinceLastRelease       bool
 }
 
 type CommitInfo struct {
@@ -75,12 +75,6 @@ type CommitInfo struct {
 	Message     string
 }
 
-type Version struct {
-	Major int
-	Minor int
-	Patch int
-}
-
 func (g *GitVersioning) validate() error {
 	if g.url == "" {
 		return errors.New("url cannot be empty")
@@ -90,17 +84,36 @@ func (g *GitVersioning) validate() error {
 		return errors.New("destination directory cannot be empty")
 	}
 
-	if g.username == "" {
-		return errors.New("username cannot be empty")
+	if g.credentials == nil {
+		return errors.New("credentials cannot be empty")
 	}
 
-	if g.password == "" {
-		return errors.New("password cannot be empty")
+	return g.credentials.Validate()
+}
+
+func (g *GitVersioning) validateLocal() error {
+	if g.destinationDirectory == "" {
+		return errors.New("repository path cannot be empty")
 	}
 
 	return nil
 }
 
+// auth returns the credentials used to talk to the remote repository.
+// Local repositories can be opened without credentials, so they are only enforced here.
+func (g *GitVersioning) auth() (transport.AuthMethod, error) {
+	if g.credentials == nil {
+		return nil, errors.New("credentials are required to push changes")
+	}
+
+	return g.credentials.Method()
+}
+
+// SetCredentials changes the credentials used to push the release. I.e.: the ones looked up once the host is inferred from the remote
+func (g *GitVersioning) SetCredentials(credentials Auth) {
+	g.credentials = credentials
+}
+
 func (g *GitVersioning) GetChangeHash() string {
 	return g.mostRecentCommit.Hash
 }
@@ -121,10 +134,36 @@ func (g *GitVersioning) GetCurrentVersion() string {
 	return g.mostRecentTag
 }
 
-func (g *GitVersioning) UpgradeRemoteRepository(newVersion string) error {
-	// consider 1.0.0 as the start tag of a repository when it does not have tags yet
+// releaseVersion considers 1.0.0 as the start tag of a repository when it does not have tags yet.
+func releaseVersion(newVersion string) string {
 	if newVersion == "0.1.0" || newVersion == "0.0.1" {
-		newVersion = "1.0.0"
+		return "1.0.0"
+	}
+	return newVersion
+}
+
+// GetCurrentTag returns the tag of the current version, or an empty string when the repository has no release yet.
+func (g *GitVersioning) GetCurrentTag() string {
+	for _, currentTag := range g.tagsList {
+		tag := strings.TrimSpace(strings.Replace(currentTag.Name, "refs/tags/", "", 1))
+		if tagVersion, ok := g.versionFromTag(tag); ok && tagVersion == g.mostRecentTag {
+			return tag
+		}
+	}
+	return ""
+}
+
+// GetReleaseTag returns the tag UpgradeRemoteRepository creates for the new version. I.e.: v1.2.0
+func (g *GitVersioning) GetReleaseTag(newVersion string) string {
+	return g.FormatTag(releaseVersion(newVersion))
+}
+
+func (g *GitVersioning) UpgradeRemoteRepository(newVersion string) error {
+	newVersion = releaseVersion(newVersion)
+
+	// the channel and the maintenance range come from the branch name, so releasing any other branch would tag the wrong history
+	if _, err := g.releaseBranch(); err != nil {
+		return err
 	}
 
 	if err := g.git.commitChanges(newVersion); err != nil {
@@ -135,7 +174,7 @@ func (g *GitVersioning) UpgradeRemoteRepository(newVersion string) error {
 		return fmt.Errorf("error during push operation due to: %w", err)
 	}
 
-	if err := g.git.setTag(newVersion); err != nil {
+	if err := g.git.setTag(g.FormatTag(newVersion)); err != nil {
 		return fmt.Errorf("error during set tag operation due to: %w", err)
 	}
 
@@ -183,6 +222,9 @@ func (g *GitVersioning) GetCommitHistory() []*object.Commit {
 }
 
 func (g *GitVersioning) GetCommitHistoryDiff() []*object.Commit {
+	if g.lintSinceLastRelease {
+		return g.commitsSinceLastRelease
+	}
 	return g.commitHistoryDiff
 }
 
@@ -251,21 +293,33 @@ func (g *GitVersioning) getMostRecentTag() (string, error) {
 		return "0.0.0", nil
 	}
 
-	mapTags := make(map[*Version]string)
-
+	var latest *semver.Version
+	var latestTag string
 	for _, currentTag := range g.tagsList {
 		tag := strings.TrimSpace(strings.Replace(currentTag.Name, "refs/tags/", "", 1))
 
-		if pattern.MatchString(tag) {
-			version := newVersion(tag)
-			mapTags[version] = tag
+		tagVersion, ok := g.versionFromTag(tag)
+		if !ok {
+			continue
 		}
-	}
 
-	var latest *Version
-	var latestTag string
-	for version, tag := range mapTags {
-		latest, latestTag = isSetNewVersion(latest, version, tag)
+		version, err := semver.Parse(tagVersion)
+		if err != nil {
+			g.log.Debug("ignoring tag %s due to: %s", tag, err.Error())
+			continue
+		}
+
+		if g.ignorePreReleaseTags && version.IsPreRelease() {
+			continue
+		}
+
+		if g.maintenanceRange != nil && !g.maintenanceRange.Contains(version) {
+			continue
+		}
+
+		if latest == nil || version.IsGreaterThan(latest) {
+			latest, latestTag = version, tagVersion
+		}
 	}
 
 	if latestTag == "" {
@@ -275,6 +329,97 @@ func (g *GitVersioning) getMostRecentTag() (string, error) {
 	return latestTag, nil
 }
 
+// FormatTag returns the tag name of a version according to the tag format. I.e.: v{version} and 1.0.0 returns v1.0.0
+func (g *GitVersioning) FormatTag(version string) string {
+	return strings.Replace(g.tagFormat, constants.VersionPlaceholder, version, 1)
+}
+
+// versionFromTag extracts the version from a tag name following the tag format.
+// Bare tags, such as 1.0.0, are also accepted when legacy tags are matched.
+func (g *GitVersioning) versionFromTag(tag string) (string, bool) {
+	index := strings.Index(g.tagFormat, constants.VersionPlaceholder)
+	prefix := g.tagFormat[:index]
+	suffix := g.tagFormat[index+len(constants.VersionPlaceholder):]
+
+	if len(tag) > len(prefix)+len(suffix) && strings.HasPrefix(tag, prefix) && strings.HasSuffix(tag, suffix) {
+		return tag[len(prefix) : len(tag)-len(suffix)], true
+	}
+
+	if _, err := semver.Parse(tag); g.matchLegacyTags && err == nil {
+		return tag, true
+	}
+
+	return "", false
+}
+
+// SetTagFormat changes the template used to find and create release tags and refreshes the current version.
+// The format must contain {version} once. I.e.: v{version} or service-name/{version}
+// When matchLegacyTags is true, bare tags such as 1.0.0 are still considered releases, which allows migrating
+// a repository to a new tag format. New tags always follow the tag format.
+func (g *GitVersioning) SetTagFormat(tagFormat string, matchLegacyTags bool) error {
+	if strings.Count(tagFormat, constants.VersionPlaceholder) != 1 {
+		return fmt.Errorf("tag format %s must contain %s exactly once", tagFormat, constants.VersionPlaceholder)
+	}
+	g.tagFormat = tagFormat
+	g.matchLegacyTags = matchLegacyTags
+
+	return g.refreshCurrentVersion()
+}
+
+// SetIgnorePreReleaseTags defines whether pre-release tags, such as 1.4.0-beta.1, are ignored when finding the current version.
+// It is used when pre-releases are published from other branches, so each release is computed from the last stable one.
+func (g *GitVersioning) SetIgnorePreReleaseTags(ignore bool) error {
+	g.ignorePreReleaseTags = ignore
+
+	return g.refreshCurrentVersion()
+}
+
+// SetMaintenanceRange restricts the tags considered when finding the current version to the range of a maintenance branch.
+// I.e.: with the range 1.2.x the current version is 1.2.3 even when 2.5.0 exists.
+func (g *GitVersioning) SetMaintenanceRange(maintenanceRange *semver.MaintenanceRange) error {
+	g.maintenanceRange = maintenanceRange
+
+	return g.refreshCurrentVersion()
+}
+
+// GetNextPreReleaseNumber returns the number of the next pre-release of a version in a channel, based on the existing tags.
+// I.e.: version 1.4.0 and channel beta returns 3 when the tags 1.4.0-beta.1 and 1.4.0-beta.2 exist
+func (g *GitVersioning) GetNextPreReleaseNumber(version, channel string) int {
+	latest := 0
+	for _, currentTag := range g.tagsList {
+		tag := strings.TrimSpace(strings.Replace(currentTag.Name, "refs/tags/", "", 1))
+
+		tagVersion, ok := g.versionFromTag(tag)
+		if !ok || !strings.HasPrefix(tagVersion, version+"-") {
+			continue
+		}
+
+		parsed, err := semver.Parse(tagVersion)
+		if err != nil || len(parsed.PreRelease) != 2 || parsed.PreRelease[0] != channel {
+			continue
+		}
+
+		if number, err := strconv.Atoi(parsed.PreRelease[1]); err == nil && number > latest {
+			latest = number
+		}
+	}
+
+	return latest + 1
+}
+
+// GetBranchName returns the name of the branch being released. It is empty when HEAD is detached.
+func (g *GitVersioning) GetBranchName() string {
+	if g.branchName != "" {
+		return g.branchName
+	}
+
+	if g.branchHead != nil && g.branchHead.Name().IsBranch() {
+		return g.branchHead.Name().Short()
+	}
+
+	return ""
+}
+
 func (g *GitVersioning) addToStage() error {
 	worktree, err := g.repo.Worktree()
 	if err != nil {
@@ -285,7 +430,7 @@ func (g *GitVersioning) addToStage() error {
 	if err != nil {
 		return err
 	}
-	g.log.Info(colorGreen + "Changes added to stage area..." + colorReset)
+	g.log.Info("Changes added to stage area...")
 	return nil
 }
 
@@ -307,17 +452,53 @@ func (g *GitVersioning) commitChanges(newReleaseVersion string) error {
 		return err
 	}
 
-	g.log.Info(colorGreen+"New commit added: %s"+colorReset, commit.String())
+	g.log.Info("New commit added: %s", commit.String())
 	return nil
 }
 
+// releaseBranch returns the branch the release is pushed to. It fails when the branch checked out is not the one informed,
+// or when HEAD is detached and no branch was informed. I.e.: the checkout of a CI job
+func (g *GitVersioning) releaseBranch() (string, error) {
+	head, err := g.repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("error while retrieving the branch pointed to HEAD due to: %w", err)
+	}
+
+	if !head.Name().IsBranch() {
+		if g.branchName == "" {
+			return "", errors.New("HEAD is detached, so the branch name must be informed to push the release")
+		}
+		return g.branchName, nil
+	}
+
+	if g.branchName != "" && head.Name().Short() != g.branchName {
+		return "", fmt.Errorf("branch %s is checked out, but the release is of branch %s", head.Name().Short(), g.branchName)
+	}
+
+	return head.Name().Short(), nil
+}
+
 func (g *GitVersioning) push() error {
-	err := g.repo.Push(&git.PushOptions{
-		Auth: &http.BasicAuth{
-			Username: g.username,
-			Password: g.password,
-		},
-		InsecureSkipTLS: true})
+	auth, err := g.auth()
+	if err != nil {
+		return err
+	}
+
+	branch, err := g.releaseBranch()
+	if err != nil {
+		return err
+	}
+
+	// a detached HEAD is not pushed along with any branch, so the release commit is pushed to the branch explicitly
+	source := plumbing.HEAD
+	if head, err := g.repo.Head(); err == nil && head.Name().IsBranch() {
+		source = head.Name()
+	}
+
+	err = g.repo.Push(&git.PushOptions{
+		RemoteName: "origin",
+		RefSpecs:   []config.RefSpec{config.RefSpec(fmt.Sprintf("%s:%s", source, plumbing.NewBranchReferenceName(branch)))},
+		Auth:       auth})
 	if err != nil {
 		return err
 	}
@@ -376,18 +557,18 @@ func (g *GitVersioning) setTag(tag string) error {
 }
 
 func (g *GitVersioning) pushTags() error {
+	auth, err := g.auth()
+	if err != nil {
+		return err
+	}
 
 	po := &git.PushOptions{
 		RemoteName: "origin",
-		Progress:   os.Stdout,
+		Progress:   os.Stderr,
 		RefSpecs:   []config.RefSpec{config.RefSpec("refs/tags/*:refs/tags/*")},
-		Auth: &http.BasicAuth{
-			Username: g.username,
-			Password: g.password,
-		},
-		InsecureSkipTLS: true,
+		Auth:       auth,
 	}
-	err := g.repo.Push(po)
+	err = g.repo.Push(po)
 
 	if err != nil {
 		return err
@@ -399,14 +580,16 @@ func (g *GitVersioning) pushTags() error {
 func (g *GitVersioning) cloneRepoToDirectory() (*git.Repository, error) {
 	defer g.printElapsedTime("CloneRepoToDirectory")()
 
-	g.log.Info(colorYellow+"cloning current repository to "+colorCyan+" %s "+colorReset, g.destinationDirectory)
+	g.log.Info("cloning current repository to %s", g.destinationDirectory)
+	auth, err := g.auth()
+	if err != nil {
+		return nil, err
+	}
+
 	opts := &git.CloneOptions{
-		Progress: os.Stdout,
+		Progress: os.Stderr,
 		URL:      g.url,
-		Auth: &http.BasicAuth{Username: g.username,
-			Password: g.password,
-		},
-		InsecureSkipTLS: true,
+		Auth:     auth,
 	}
 
 	repo, err := git.PlainClone(g.destinationDirectory, false, opts)
@@ -424,6 +607,34 @@ func (g *GitVersioning) cloneRepoToDirectory() (*git.Repository, error) {
 	return nil, err
 }
 
+func (g *GitVersioning) openRepository() (*git.Repository, error) {
+	defer g.printElapsedTime("OpenRepository")()
+
+	g.log.Info("opening local repository at %s", g.destinationDirectory)
+	repo, err := git.PlainOpen(g.destinationDirectory)
+	if err != nil {
+		g.log.Error("error while opening local repository due to: %s", err)
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+// GetRemoteURL returns the first URL configured for the origin remote.
+func (g *GitVersioning) GetRemoteURL() (string, error) {
+	remote, err := g.repo.Remote("origin")
+	if err != nil {
+		return "", fmt.Errorf("error while getting origin remote due to: %w", err)
+	}
+
+	urls := remote.Config().URLs
+	if len(urls) == 0 {
+		return "", errors.New("origin remote has no url")
+	}
+
+	return urls[0], nil
+}
+
 func (g *GitVersioning) setBranchHead() error {
 	branchHead, err := g.git.getBranchPointedToHead()
 	if err != nil {
@@ -435,7 +646,7 @@ func (g *GitVersioning) setBranchHead() error {
 
 func (g *GitVersioning) getBranchReference(branchName string) (*plumbing.Reference, error) {
 	defer g.printElapsedTime("getBranchReference")()
-	branchName = fmt.Sprintf("/refs/remotes/origin/%s", branchName)
+	branchName = fmt.Sprintf("refs/remotes/origin/%s", branchName)
 	g.log.Info("getting branch pointed to %s", branchName)
 	ref, err := g.repo.Reference(plumbing.ReferenceName(branchName), false)
 	if err != nil {
@@ -454,6 +665,35 @@ func (g *GitVersioning) setReferenceBranch(branchName string) error {
 	return nil
 }
 
+// checkoutBranch checks out the branch informed, so the release is committed and tagged on its history.
+// A branch already checked out by a previous clone is reused.
+func (g *GitVersioning) checkoutBranch() error {
+	branch := plumbing.NewBranchReferenceName(g.branchName)
+
+	head, err := g.repo.Head()
+	if err == nil && head.Name() == branch {
+		return nil
+	}
+
+	worktree, err := g.repo.Worktree()
+	if err != nil {
+		return err
+	}
+
+	options := &git.CheckoutOptions{Branch: branch}
+	if _, err := g.repo.Reference(branch, false); err == plumbing.ErrReferenceNotFound {
+		options.Create = true
+		options.Hash = g.branchHead.Hash()
+	}
+
+	g.log.Info("checking out branch %s", g.branchName)
+	if err := worktree.Checkout(options); err != nil {
+		return fmt.Errorf("error while checking out branch %s due to: %w", g.branchName, err)
+	}
+
+	return nil
+}
+
 func (g *GitVersioning) getCurrentBranchCommitsDiff() []*object.Commit {
 	var commitHistoryDiff []*object.Commit
 	found := false
@@ -471,34 +711,91 @@ func (g *GitVersioning) getCurrentBranchCommitsDiff() []*object.Commit {
 	return commitHistoryDiff
 }
 
-func (g *GitVersioning) initialize() error {
-	if g.branchName != "" {
-		err := g.setReferenceBranch(g.branchName)
-		if err != nil {
-			return err
-		}
-		commitHistoryCurrentBranch, err := g.git.getCommitHistory()
-		if err != nil {
-			return fmt.Errorf("error while retrieving the commit history due to: %w", err)
+// getDefaultBranchReference finds the default branch of origin, which the commits checked out locally are compared to.
+func (g *GitVersioning) getDefaultBranchReference() (*plumbing.Reference, error) {
+	if ref, err := g.repo.Reference("refs/remotes/origin/HEAD", true); err == nil {
+		return ref, nil
+	}
+
+	for _, branchName := range []string{"main", "master"} {
+		if ref, err := g.git.getBranchReference(branchName); err == nil {
+			return ref, nil
 		}
-		g.commitHistoryCurrentBranch = commitHistoryCurrentBranch
 	}
 
-	err := g.setBranchHead()
+	return nil, errors.New("origin/HEAD, origin/main and origin/master were not found")
+}
+
+// setCommitHistoryDiff finds the commits of the branch informed that are not in the default branch, so they are linted.
+// A clone compares the remote branch to the default branch it checked out. A local checkout usually has the remote branch
+// pointing to HEAD itself, so HEAD is compared to the default branch of origin instead.
+func (g *GitVersioning) setCommitHistoryDiff() error {
+	var err error
+	if g.cloned {
+		err = g.setReferenceBranch(g.branchName)
+	} else {
+		err = g.setBranchHead()
+	}
 	if err != nil {
 		return err
 	}
 
+	commitHistoryCurrentBranch, err := g.git.getCommitHistory()
+	if err != nil {
+		return fmt.Errorf("error while retrieving the commit history due to: %w", err)
+	}
+	g.commitHistoryCurrentBranch = commitHistoryCurrentBranch
+
+	if g.cloned {
+		if err := g.setBranchHead(); err != nil {
+			return err
+		}
+	} else {
+		defaultBranch, err := g.getDefaultBranchReference()
+		if err != nil {
+			g.log.Warn("default branch not found, so the commits since the last release are linted: %s", err.Error())
+			g.lintSinceLastRelease = true
+			return nil
+		}
+		g.branchHead = defaultBranch
+	}
+
 	commitHistory, err := g.git.getCommitHistory()
 	if err != nil {
 		return fmt.Errorf("error while retrieving the commit history due to: %w", err)
 	}
 	g.commitHistory = commitHistory
+	g.commitHistoryDiff = g.getCurrentBranchCommitsDiff()
+	return nil
+}
 
+func (g *GitVersioning) initialize() error {
 	if g.branchName != "" {
-		g.commitHistoryDiff = g.getCurrentBranchCommitsDiff()
+		if err := g.setCommitHistoryDiff(); err != nil {
+			return err
+		}
+	}
+
+	if g.cloned && g.branchName != "" {
+		if err := g.setReferenceBranch(g.branchName); err != nil {
+			return err
+		}
+
+		if err := g.checkoutBranch(); err != nil {
+			return err
+		}
 	}
 
+	if err := g.setBranchHead(); err != nil {
+		return err
+	}
+
+	commitHistory, err := g.git.getCommitHistory()
+	if err != nil {
+		return fmt.Errorf("error while retrieving the commit history due to: %w", err)
+	}
+	g.commitHistory = commitHistory
+
 	mostRecentCommit, err := g.git.getMostRecentCommit()
 	if err != nil {
 		return fmt.Errorf("error while retrieving tags from repository due to: %w", err)
@@ -511,53 +808,124 @@ func (g *GitVersioning) initialize() error {
 	}
 	g.tagsList = allTags
 
+	return g.refreshCurrentVersion()
+}
+
+// refreshCurrentVersion finds the most recent release and the commits made after it.
+func (g *GitVersioning) refreshCurrentVersion() error {
 	mostRecentTag, err := g.git.getMostRecentTag()
 	if err != nil {
 		return fmt.Errorf("error while getting most recent tage due to: %w", err)
 	}
 	g.mostRecentTag = mostRecentTag
 
+	commitsSinceLastRelease, err := g.getCommitsSinceVersion(mostRecentTag)
+	if err != nil {
+		return fmt.Errorf("error while getting commits since last release due to: %w", err)
+	}
+	g.commitsSinceLastRelease = commitsSinceLastRelease
+
 	return nil
 }
 
-func newVersion(tag string) *Version {
-	segments := strings.Split(tag, ".")
-	major, _ := strconv.Atoi(segments[0])
-	minor, _ := strconv.Atoi(segments[1])
-	patch, _ := strconv.Atoi(segments[2])
+// getReleaseCommitHash returns the hash of the commit tagged with the given version.
+// Annotated tags are peeled to the commit they point to.
+func (g *GitVersioning) getReleaseCommitHash(version string) (plumbing.Hash, bool, error) {
+	for _, currentTag := range g.tagsList {
+		tag := strings.TrimSpace(strings.Replace(currentTag.Name, "refs/tags/", "", 1))
+		if tagVersion, ok := g.versionFromTag(tag); !ok || tagVersion != version {
+			continue
+		}
+
+		tagObject, err := g.repo.TagObject(currentTag.Hash)
+		if err == plumbing.ErrObjectNotFound {
+			return currentTag.Hash, true, nil
+		}
+		if err != nil {
+			return plumbing.ZeroHash, false, err
+		}
 
-	return &Version{
-		Major: major,
-		Minor: minor,
-		Patch: patch,
+		commit, err := tagObject.Commit()
+		if err != nil {
+			return plumbing.ZeroHash, false, err
+		}
+		return commit.Hash, true, nil
 	}
+
+	return plumbing.ZeroHash, false, nil
 }
 
-func isSetNewVersion(latest, version *Version, tag string) (*Version, string) {
-	if latest == nil || version.isGreaterThan(latest) {
-		return version, tag
+// getCommitsSinceVersion returns the commits of the history that are not reachable from the release of the given version.
+// The whole history is returned when there is no such release.
+func (g *GitVersioning) getCommitsSinceVersion(version string) ([]*object.Commit, error) {
+	releaseHash, found, err := g.getReleaseCommitHash(version)
+	if err != nil {
+		return nil, err
 	}
-	return latest, fmt.Sprintf("%d.%d.%d", latest.Major, latest.Minor, latest.Patch)
-}
 
-func (v *Version) isGreaterThan(other *Version) bool {
-	if v.Major != other.Major {
-		return v.Major > other.Major
+	if !found {
+		return g.commitHistory, nil
 	}
-	if v.Minor != other.Minor {
-		return v.Minor > other.Minor
+
+	cIter, err := g.repo.Log(&git.LogOptions{From: releaseHash})
+	if err != nil {
+		return nil, err
+	}
+
+	released := make(map[plumbing.Hash]bool)
+	err = cIter.ForEach(func(c *object.Commit) error {
+		released[c.Hash] = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []*object.Commit
+	for _, commit := range g.commitHistory {
+		if !released[commit.Hash] {
+			commits = append(commits, commit)
+		}
+	}
+
+	return commits, nil
+}
+
+// GetCommitsSinceLastRelease returns the commits made after the most recent release, the most recent first.
+func (g *GitVersioning) GetCommitsSinceLastRelease() []*object.Commit {
+	return g.commitsSinceLastRelease
+}
+
+func (g *GitVersioning) bindMethods() {
+	g.git = GitMethods{
+		getBranchPointedToHead: g.getBranchPointedToHead,
+		getBranchReference:     g.getBranchReference,
+		getCommitHistory:       g.getCommitHistory,
+		getMostRecentCommit:    g.getMostRecentCommit,
+		getAllTags:             g.getAllTags,
+		getMostRecentTag:       g.getMostRecentTag,
+		addToStage:             g.addToStage,
+		commitChanges:          g.commitChanges,
+		push:                   g.push,
+		tagExists:              g.tagExists,
+		setTag:                 g.setTag,
+		pushTags:               g.pushTags,
 	}
-	return v.Patch > other.Patch
 }
 
 func New(log Logger, printElapsedTime ElapsedTime, url, username, password, destinationDirectory string, branchName string) (*GitVersioning, error) {
+	return NewWithAuth(log, printElapsedTime, url, &BasicAuth{Username: username, Password: password}, destinationDirectory, branchName)
+}
+
+// NewWithAuth clones the repository at url authenticating with the given credentials. I.e.: BasicAuth or SSHAuth
+func NewWithAuth(log Logger, printElapsedTime ElapsedTime, url string, credentials Auth, destinationDirectory string, branchName string) (*GitVersioning, error) {
 	gitLabVersioning := &GitVersioning{
 		log:                  log,
 		printElapsedTime:     printElapsedTime,
-		username:             username,
-		password:             password,
+		credentials:          credentials,
 		url:                  url,
 		destinationDirectory: destinationDirectory,
+		tagFormat:            defaultTagFormat,
 	}
 
 	if err := gitLabVersioning.validate(); err != nil {
@@ -571,22 +939,45 @@ func New(log Logger, printElapsedTime ElapsedTime, url, username, password, dest
 	}
 
 	gitLabVersioning.repo = repo
+	gitLabVersioning.cloned = true
+	gitLabVersioning.bindMethods()
+
+	if err := gitLabVersioning.initialize(); err != nil {
+		return nil, err
+	}
+
+	return gitLabVersioning, nil
+}
+
+// NewLocal opens the repository already checked out at repositoryPath instead of cloning it.
+// The remotes of the working tree are reused and the credentials are only required when pushing.
+func NewLocal(log Logger, printElapsedTime ElapsedTime, username, password, repositoryPath string, branchName string) (*GitVersioning, error) {
+	return NewLocalWithAuth(log, printElapsedTime, &BasicAuth{Username: username, Password: password}, repositoryPath, branchName)
+}
 
-	gitLabVersioning.git = GitMethods{
-		getBranchPointedToHead: gitLabVersioning.getBranchPointedToHead,
-		getBranchReference:     gitLabVersioning.getBranchReference,
-		getCommitHistory:       gitLabVersioning.getCommitHistory,
-		getMostRecentCommit:    gitLabVersioning.getMostRecentCommit,
-		getAllTags:             gitLabVersioning.getAllTags,
-		getMostRecentTag:       gitLabVersioning.getMostRecentTag,
-		addToStage:             gitLabVersioning.addToStage,
-		commitChanges:          gitLabVersioning.commitChanges,
-		push:                   gitLabVersioning.push,
-		tagExists:              gitLabVersioning.tagExists,
-		setTag:                 gitLabVersioning.setTag,
-		pushTags:               gitLabVersioning.pushTags,
+// NewLocalWithAuth opens the repository already checked out at repositoryPath, pushing with the given credentials.
+func NewLocalWithAuth(log Logger, printElapsedTime ElapsedTime, credentials Auth, repositoryPath string, branchName string) (*GitVersioning, error) {
+	gitLabVersioning := &GitVersioning{
+		log:                  log,
+		printElapsedTime:     printElapsedTime,
+		credentials:          credentials,
+		destinationDirectory: repositoryPath,
+		branchName:           branchName,
+		tagFormat:            defaultTagFormat,
 	}
 
+	if err := gitLabVersioning.validateLocal(); err != nil {
+		return nil, err
+	}
+
+	repo, err := gitLabVersioning.openRepository()
+	if err != nil {
+		return nil, fmt.Errorf("error while initiating git package due to : %w", err)
+	}
+
+	gitLabVersioning.repo = repo
+	gitLabVersioning.bindMethods()
+
 	if err := gitLabVersioning.initialize(); err != nil {
 		return nil, err
 	}
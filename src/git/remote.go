@@ -0,0 +1,61 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// RemoteInfo holds the coordinates of a project hosted on a git server.
+type RemoteInfo struct {
+	Host    string
+	Group   string
+	Project string
+}
+
+// ParseRemoteURL extracts host, group and project from a remote URL.
+// It understands https, ssh and scp-like remotes. I.e.:
+//
+//	https://gitlab.com/group/subgroup/project.git
+//	ssh://git@gitlab.com:2222/group/project.git
+//	git@gitlab.com:group/project.git
+func ParseRemoteURL(remoteURL string) (RemoteInfo, error) {
+	remoteURL = strings.TrimSpace(remoteURL)
+	if remoteURL == "" {
+		return RemoteInfo{}, errors.New("remote url cannot be empty")
+	}
+
+	var host, path string
+	if strings.Contains(remoteURL, "://") {
+		parsed, err := url.Parse(remoteURL)
+		if err != nil {
+			return RemoteInfo{}, fmt.Errorf("error while parsing remote url due to: %w", err)
+		}
+
+		host = parsed.Host
+		if parsed.Scheme == "ssh" {
+			host = parsed.Hostname()
+		}
+		path = parsed.Path
+	} else {
+		index := strings.Index(remoteURL, ":")
+		if index == -1 {
+			return RemoteInfo{}, fmt.Errorf("remote url %s is not supported", remoteURL)
+		}
+
+		host = remoteURL[:index]
+		if at := strings.LastIndex(host, "@"); at != -1 {
+			host = host[at+1:]
+		}
+		path = remoteURL[index+1:]
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	index := strings.LastIndex(path, "/")
+	if host == "" || index == -1 {
+		return RemoteInfo{}, fmt.Errorf("remote url %s must follow the pattern host/group/project", remoteURL)
+	}
+
+	return RemoteInfo{Host: host, Group: path[:index], Project: path[index+1:]}, nil
+}
@@ -0,0 +1,99 @@
+package git
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+// TLSConfig configures how HTTPS remotes are verified.
+// The zero value verifies the server certificate against the system certificate authorities.
+type TLSConfig struct {
+	// CABundlePath is a PEM file with certificate authorities trusted along with the system ones. I.e.: a private CA
+	CABundlePath string
+	// ClientCertPath and ClientKeyPath are the PEM certificate and key presented when the server requires mutual TLS.
+	ClientCertPath string
+	ClientKeyPath  string
+	// Insecure skips the verification of the server certificate. It must only be used for testing.
+	Insecure bool
+}
+
+func (c *TLSConfig) validate() error {
+	if (c.ClientCertPath == "") != (c.ClientKeyPath == "") {
+		return errors.New("client certificate and client key must be informed together")
+	}
+
+	return nil
+}
+
+// Config returns the TLS configuration of HTTPS clients.
+func (c *TLSConfig) Config() (*tls.Config, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: c.Insecure,
+	}
+
+	if c.CABundlePath != "" {
+		bundle, err := os.ReadFile(c.CABundlePath)
+		if err != nil {
+			return nil, fmt.Errorf("error while reading ca bundle due to: %w", err)
+		}
+
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil || rootCAs == nil {
+			rootCAs = x509.NewCertPool()
+		}
+
+		if !rootCAs.AppendCertsFromPEM(bundle) {
+			return nil, fmt.Errorf("ca bundle %s has no PEM certificate", c.CABundlePath)
+		}
+		config.RootCAs = rootCAs
+	}
+
+	if c.ClientCertPath != "" {
+		certificate, err := tls.LoadX509KeyPair(c.ClientCertPath, c.ClientKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("error while loading client certificate due to: %w", err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	return config, nil
+}
+
+// HTTPClient returns an HTTP client using the TLS configuration and the proxy of the environment.
+func (c *TLSConfig) HTTPClient() (*http.Client, error) {
+	config, err := c.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: config,
+		},
+	}, nil
+}
+
+// InstallTLS makes every HTTPS clone and push use the TLS configuration.
+// It must be called before creating the GitVersioning, since the repository is cloned by New.
+func InstallTLS(c *TLSConfig) error {
+	httpClient, err := c.HTTPClient()
+	if err != nil {
+		return err
+	}
+
+	client.InstallProtocol("https", githttp.NewClient(httpClient))
+	return nil
+}
@@ -0,0 +1,90 @@
+package log
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	mask               = "***"
+	secretGroup        = "secret"
+	urlUserinfoPattern = `[A-Za-z][A-Za-z0-9+.-]*://(?P<secret>[^/?#@\s]+)@`
+)
+
+// redactor masks registered secrets and the matches of registered patterns.
+type redactor struct {
+	mutex    sync.RWMutex
+	secrets  []string
+	patterns []*regexp.Regexp
+}
+
+func (r *redactor) addSecrets(secrets ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, secret := range secrets {
+		if secret != "" {
+			r.secrets = append(r.secrets, secret)
+		}
+	}
+
+	// a secret containing another one must be masked first, otherwise part of it would leak
+	sort.SliceStable(r.secrets, func(i, j int) bool {
+		return len(r.secrets[i]) > len(r.secrets[j])
+	})
+}
+
+func (r *redactor) addPattern(pattern string) error {
+	expression, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("error while compiling secret pattern due to: %w", err)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.patterns = append(r.patterns, expression)
+	return nil
+}
+
+// maskMatches replaces the matches of expression, or only their secret group when it exists, with the mask.
+func maskMatches(expression *regexp.Regexp, message string) string {
+	group := expression.SubexpIndex(secretGroup)
+	if group == -1 {
+		return expression.ReplaceAllString(message, mask)
+	}
+
+	var result strings.Builder
+	last := 0
+	for _, match := range expression.FindAllStringSubmatchIndex(message, -1) {
+		start, end := match[2*group], match[2*group+1]
+		if start == -1 {
+			continue
+		}
+
+		result.WriteString(message[last:start])
+		result.WriteString(mask)
+		last = end
+	}
+	result.WriteString(message[last:])
+
+	return result.String()
+}
+
+func (r *redactor) redact(message string) string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, secret := range r.secrets {
+		message = strings.ReplaceAll(message, secret, mask)
+	}
+
+	for _, expression := range r.patterns {
+		message = maskMatches(expression, message)
+	}
+
+	return message
+}
@@ -0,0 +1,133 @@
+package release
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/NeowayLabs/semantic-release/src/semver"
+)
+
+const (
+	gitHubHost       = "github.com"
+	gitHubAPIURL     = "https://api.github.com"
+	gitHubAPIVersion = "2022-11-28"
+)
+
+// GitHub creates releases through the GitHub REST API, on github.com or GitHub Enterprise Server.
+// See https://docs.github.com/en/rest/releases/releases#create-a-release
+type GitHub struct {
+	api     apiClient
+	baseURL string
+	owner   string
+	repo    string
+	draft   bool
+}
+
+type gitHubRelease struct {
+	TagName    string `json:"tag_name"`
+	Name       string `json:"name"`
+	Body       string `json:"body"`
+	Draft      bool   `json:"draft"`
+	PreRelease bool   `json:"prerelease"`
+}
+
+// DefaultGitHubAPIURL returns the API URL of a GitHub host. I.e.: https://api.github.com for github.com
+// and https://github.example.com/api/v3 for GitHub Enterprise Server.
+func DefaultGitHubAPIURL(host string) string {
+	if host == "" || host == gitHubHost {
+		return gitHubAPIURL
+	}
+	return fmt.Sprintf("https://%s/api/v3", host)
+}
+
+func (g *GitHub) validate() error {
+	if g.baseURL == "" {
+		return errors.New("api url cannot be empty")
+	}
+
+	if g.owner == "" || g.repo == "" {
+		return errors.New("owner and repository cannot be empty")
+	}
+
+	if g.api.headers["Authorization"] == "" {
+		return errors.New("token cannot be empty")
+	}
+
+	return nil
+}
+
+// SetDraft makes every release a draft, which is only published once reviewed in GitHub.
+func (g *GitHub) SetDraft(draft bool) {
+	g.draft = draft
+}
+
+// SetRetries changes how many times a request is retried when GitHub is unavailable, and the delay between attempts.
+// The delay increases linearly at each attempt.
+func (g *GitHub) SetRetries(maxRetries int, retryDelay time.Duration) {
+	g.api.maxRetries = maxRetries
+	g.api.retryDelay = retryDelay
+}
+
+// isPreRelease returns true when the version has pre-release identifiers. I.e.: 1.4.0-beta.2
+func isPreRelease(version string) bool {
+	parsed, err := semver.Parse(version)
+	return err == nil && parsed.IsPreRelease()
+}
+
+// Publish creates the release of an existing tag, described by the release notes in markdown.
+// Pre-release versions are marked as pre-releases.
+func (g *GitHub) Publish(tagName, version, description string) error {
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/releases", strings.TrimSuffix(g.baseURL, "/"), g.owner, g.repo)
+
+	g.api.log.Info("creating release %s of repository %s/%s", tagName, g.owner, g.repo)
+	response, err := g.api.postJSON(endpoint, gitHubRelease{
+		TagName:    tagName,
+		Name:       tagName,
+		Body:       description,
+		Draft:      g.draft,
+		PreRelease: isPreRelease(version),
+	})
+	if err != nil {
+		return fmt.Errorf("error while creating github release due to: %w", err)
+	}
+
+	if response.statusCode == http.StatusUnprocessableEntity && response.retried && bytes.Contains(response.body, []byte(`"already_exists"`)) {
+		g.api.log.Warn("release %s already exists, so it was created by a previous attempt", tagName)
+		return nil
+	}
+
+	if response.statusCode != http.StatusCreated {
+		return fmt.Errorf("error while creating github release due to: unexpected status %d: %s", response.statusCode, truncate(response.body))
+	}
+
+	return nil
+}
+
+// NewGitHub returns a GitHub client creating releases of the repository owner/repo.
+func NewGitHub(log Logger, client *http.Client, baseURL, owner, repo, token string) (*GitHub, error) {
+	headers := map[string]string{
+		"Accept":               "application/vnd.github+json",
+		"X-GitHub-Api-Version": gitHubAPIVersion,
+	}
+
+	if token != "" {
+		headers["Authorization"] = "Bearer " + token
+	}
+
+	gitHub := &GitHub{
+		api:     newAPIClient(log, client, headers),
+		baseURL: baseURL,
+		owner:   owner,
+		repo:    repo,
+	}
+
+	if err := gitHub.validate(); err != nil {
+		return nil, err
+	}
+
+	return gitHub, nil
+}
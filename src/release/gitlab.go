@@ -0,0 +1,146 @@
+package release
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// GitLabPrivateTokenHeader authenticates with a personal, group or project access token.
+	GitLabPrivateTokenHeader = "PRIVATE-TOKEN"
+	// GitLabJobTokenHeader authenticates with the job token of GitLab CI. I.e.: ${CI_JOB_TOKEN}
+	GitLabJobTokenHeader = "JOB-TOKEN"
+)
+
+// GitLab creates releases through the GitLab Releases API.
+// See https://docs.gitlab.com/ee/api/releases/#create-a-release
+type GitLab struct {
+	api        apiClient
+	baseURL    string
+	project    string
+	milestones []string
+	assets     []AssetLink
+}
+
+type gitLabAssetLink struct {
+	Name     string `json:"name"`
+	URL      string `json:"url"`
+	LinkType string `json:"link_type,omitempty"`
+}
+
+type gitLabAssets struct {
+	Links []gitLabAssetLink `json:"links"`
+}
+
+type gitLabRelease struct {
+	TagName     string        `json:"tag_name"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Milestones  []string      `json:"milestones,omitempty"`
+	Assets      *gitLabAssets `json:"assets,omitempty"`
+}
+
+// DefaultGitLabAPIURL returns the API URL of a GitLab host. I.e.: https://gitlab.com/api/v4
+func DefaultGitLabAPIURL(host string) string {
+	return fmt.Sprintf("https://%s/api/v4", host)
+}
+
+func (g *GitLab) validate() error {
+	if g.baseURL == "" {
+		return errors.New("api url cannot be empty")
+	}
+
+	if g.project == "" {
+		return errors.New("project cannot be empty")
+	}
+
+	if len(g.api.headers) == 0 {
+		return errors.New("token cannot be empty")
+	}
+
+	return nil
+}
+
+// SetMilestones associates every release to the milestones with the given titles.
+func (g *GitLab) SetMilestones(milestones []string) {
+	g.milestones = milestones
+}
+
+// SetAssetLinks attaches the links to every release.
+func (g *GitLab) SetAssetLinks(assets []AssetLink) {
+	g.assets = assets
+}
+
+// SetRetries changes how many times a request is retried when GitLab is unavailable, and the delay between attempts.
+// The delay increases linearly at each attempt.
+func (g *GitLab) SetRetries(maxRetries int, retryDelay time.Duration) {
+	g.api.maxRetries = maxRetries
+	g.api.retryDelay = retryDelay
+}
+
+func (g *GitLab) payload(tagName, description string) gitLabRelease {
+	release := gitLabRelease{
+		TagName:     tagName,
+		Name:        tagName,
+		Description: description,
+		Milestones:  g.milestones,
+	}
+
+	if len(g.assets) > 0 {
+		release.Assets = &gitLabAssets{}
+		for _, asset := range g.assets {
+			release.Assets.Links = append(release.Assets.Links, gitLabAssetLink{Name: asset.Name, URL: asset.URL, LinkType: asset.Type})
+		}
+	}
+
+	return release
+}
+
+// Publish creates the release of an existing tag, described by the release notes in markdown.
+// GitLab has no pre-release flag, so the version is not used.
+func (g *GitLab) Publish(tagName, version, description string) error {
+	// the project may be informed by its path, which must be encoded. I.e.: group%2Fproject
+	endpoint := fmt.Sprintf("%s/projects/%s/releases", strings.TrimSuffix(g.baseURL, "/"), url.PathEscape(g.project))
+
+	g.api.log.Info("creating release %s of project %s", tagName, g.project)
+	response, err := g.api.postJSON(endpoint, g.payload(tagName, description))
+	if err != nil {
+		return fmt.Errorf("error while creating gitlab release due to: %w", err)
+	}
+
+	if response.statusCode == http.StatusConflict && response.retried {
+		g.api.log.Warn("release %s already exists, so it was created by a previous attempt", tagName)
+		return nil
+	}
+
+	if response.statusCode != http.StatusCreated {
+		return fmt.Errorf("error while creating gitlab release due to: unexpected status %d: %s", response.statusCode, truncate(response.body))
+	}
+
+	return nil
+}
+
+// NewGitLab returns a GitLab client creating releases of a project, informed by its id or path. I.e.: group/project
+// The token is sent in tokenHeader, which is GitLabPrivateTokenHeader or GitLabJobTokenHeader.
+func NewGitLab(log Logger, client *http.Client, baseURL, project, tokenHeader, token string) (*GitLab, error) {
+	headers := make(map[string]string)
+	if token != "" {
+		headers[tokenHeader] = token
+	}
+
+	gitLab := &GitLab{
+		api:     newAPIClient(log, client, headers),
+		baseURL: baseURL,
+		project: project,
+	}
+
+	if err := gitLab.validate(); err != nil {
+		return nil, err
+	}
+
+	return gitLab, nil
+}
@@ -0,0 +1,129 @@
+package release
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultMaxRetries = 3
+	defaultRetryDelay = 2 * time.Second
+	// maxErrorBodySize limits how much of an error response is kept in the error message.
+	maxErrorBodySize = 512
+)
+
+type Logger interface {
+	Info(s string, args ...interface{})
+	Warn(s string, args ...interface{})
+}
+
+// AssetLink is a link attached to a release. I.e.: the package published to a registry
+type AssetLink struct {
+	Name string
+	URL  string
+	// Type is one of other, runbook, image or package. It defaults to other.
+	Type string
+}
+
+// apiClient sends requests to the REST API of a git hosting provider, retrying when it is unavailable.
+type apiClient struct {
+	log        Logger
+	client     *http.Client
+	headers    map[string]string
+	maxRetries int
+	retryDelay time.Duration
+}
+
+func newAPIClient(log Logger, client *http.Client, headers map[string]string) apiClient {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return apiClient{
+		log:        log,
+		client:     client,
+		headers:    headers,
+		maxRetries: defaultMaxRetries,
+		retryDelay: defaultRetryDelay,
+	}
+}
+
+// apiResponse is the response of a request to the API of a provider.
+type apiResponse struct {
+	statusCode int
+	body       []byte
+	// retried is true when the request was sent more than once, so a previous attempt may have been processed without its response being received.
+	retried bool
+}
+
+// isRetryable returns true when the request may succeed if sent again. I.e.: 429 Too Many Requests or 502 Bad Gateway
+func isRetryable(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+func (c *apiClient) send(method, url string, body []byte) (*http.Response, error) {
+	request, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json")
+	for key, value := range c.headers {
+		request.Header.Set(key, value)
+	}
+
+	return c.client.Do(request)
+}
+
+// postJSON posts payload to url and returns the response.
+// Network errors, 429 and 5xx responses are retried up to maxRetries times, waiting retryDelay more at each attempt.
+// A POST is not idempotent, so the callers must accept the resource created by a previous attempt when the response is retried.
+func (c *apiClient) postJSON(url string, payload interface{}) (apiResponse, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return apiResponse{}, fmt.Errorf("error while marshalling request due to: %w", err)
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if attempt > 0 {
+			c.log.Warn("retrying request in %s due to: %s", c.retryDelay*time.Duration(attempt), lastErr)
+			time.Sleep(c.retryDelay * time.Duration(attempt))
+		}
+
+		response, err := c.send(http.MethodPost, url, body)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		responseBody, err := io.ReadAll(response.Body)
+		response.Body.Close()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if isRetryable(response.StatusCode) {
+			lastErr = fmt.Errorf("unexpected status %d", response.StatusCode)
+			continue
+		}
+
+		return apiResponse{statusCode: response.StatusCode, body: responseBody, retried: attempt > 0}, nil
+	}
+
+	return apiResponse{}, fmt.Errorf("request failed after %d attempts due to: %w", c.maxRetries+1, lastErr)
+}
+
+// truncate keeps the beginning of an error response, which is enough to understand it.
+func truncate(body []byte) string {
+	if len(body) > maxErrorBodySize {
+		return string(body[:maxErrorBodySize]) + "..."
+	}
+	return string(body)
+}
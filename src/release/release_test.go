@@ -0,0 +1,218 @@
+//go:build unit
+// +build unit
+
+package release_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NeowayLabs/semantic-release/src/log"
+	"github.com/NeowayLabs/semantic-release/src/release"
+	"github.com/NeowayLabs/semantic-release/src/tests"
+)
+
+func newLogger(t *testing.T) *log.Log {
+	logger, err := log.New("test", "1.0.0", "debug")
+	if err != nil {
+		t.Errorf("error while getting log due to %s", err.Error())
+	}
+	return logger
+}
+
+func newGitLab(t *testing.T, server *httptest.Server) *release.GitLab {
+	gitLab, err := release.NewGitLab(newLogger(t), server.Client(), server.URL+"/api/v4", "group/project", release.GitLabPrivateTokenHeader, "token")
+	tests.AssertNoError(t, err)
+	gitLab.SetRetries(2, time.Millisecond)
+	return gitLab
+}
+
+func TestGitLabPublishNoError(t *testing.T) {
+	var payload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tests.AssertEqualValues(t, http.MethodPost, r.Method)
+		tests.AssertEqualValues(t, "/api/v4/projects/group%2Fproject/releases", r.URL.EscapedPath())
+		tests.AssertEqualValues(t, "token", r.Header.Get("PRIVATE-TOKEN"))
+		tests.AssertNoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	gitLab := newGitLab(t, server)
+	gitLab.SetMilestones([]string{"q1"})
+	gitLab.SetAssetLinks([]release.AssetLink{{Name: "docker image", URL: "https://registry.com/group/project", Type: "image"}})
+
+	err := gitLab.Publish("v1.2.0", "1.2.0", "## v1.2.0\n- feat - new feature\n")
+	tests.AssertNoError(t, err)
+
+	expected := map[string]interface{}{
+		"tag_name":    "v1.2.0",
+		"name":        "v1.2.0",
+		"description": "## v1.2.0\n- feat - new feature\n",
+		"milestones":  []interface{}{"q1"},
+		"assets": map[string]interface{}{
+			"links": []interface{}{
+				map[string]interface{}{"name": "docker image", "url": "https://registry.com/group/project", "link_type": "image"},
+			},
+		},
+	}
+	tests.AssertDeepEqualValues(t, expected, payload)
+}
+
+func TestGitLabPublishRetryNoError(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	err := newGitLab(t, server).Publish("v1.2.0", "1.2.0", "notes")
+	tests.AssertNoError(t, err)
+	tests.AssertEqualValues(t, 3, attempts)
+}
+
+func TestGitLabPublishRetriesExhaustedError(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	err := newGitLab(t, server).Publish("v1.2.0", "1.2.0", "notes")
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, "error while creating gitlab release due to: request failed after 3 attempts due to: unexpected status 503", err.Error())
+	tests.AssertEqualValues(t, 3, attempts)
+}
+
+func TestGitLabPublishConflictError(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"message":"Release already exists"}`))
+	}))
+	defer server.Close()
+
+	err := newGitLab(t, server).Publish("v1.2.0", "1.2.0", "notes")
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, `error while creating gitlab release due to: unexpected status 409: {"message":"Release already exists"}`, err.Error())
+	tests.AssertEqualValues(t, 1, attempts)
+}
+
+func TestNewGitLabError(t *testing.T) {
+	logger := newLogger(t)
+
+	_, err := release.NewGitLab(logger, nil, "", "group/project", release.GitLabPrivateTokenHeader, "token")
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, "api url cannot be empty", err.Error())
+
+	_, err = release.NewGitLab(logger, nil, "https://gitlab.com/api/v4", "", release.GitLabPrivateTokenHeader, "token")
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, "project cannot be empty", err.Error())
+
+	_, err = release.NewGitLab(logger, nil, "https://gitlab.com/api/v4", "group/project", release.GitLabJobTokenHeader, "")
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, "token cannot be empty", err.Error())
+}
+
+func TestGitHubPublishNoError(t *testing.T) {
+	var payloads []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tests.AssertEqualValues(t, http.MethodPost, r.Method)
+		tests.AssertEqualValues(t, "/api/v3/repos/owner/project/releases", r.URL.Path)
+		tests.AssertEqualValues(t, "Bearer token", r.Header.Get("Authorization"))
+		tests.AssertEqualValues(t, "application/vnd.github+json", r.Header.Get("Accept"))
+
+		var payload map[string]interface{}
+		tests.AssertNoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		payloads = append(payloads, payload)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	gitHub, err := release.NewGitHub(newLogger(t), server.Client(), server.URL+"/api/v3/", "owner", "project", "token")
+	tests.AssertNoError(t, err)
+	gitHub.SetDraft(true)
+
+	tests.AssertNoError(t, gitHub.Publish("v1.2.0", "1.2.0", "## v1.2.0\n"))
+	tests.AssertNoError(t, gitHub.Publish("v1.3.0-beta.1", "1.3.0-beta.1", "## v1.3.0-beta.1\n"))
+
+	expected := []map[string]interface{}{
+		{"tag_name": "v1.2.0", "name": "v1.2.0", "body": "## v1.2.0\n", "draft": true, "prerelease": false},
+		{"tag_name": "v1.3.0-beta.1", "name": "v1.3.0-beta.1", "body": "## v1.3.0-beta.1\n", "draft": true, "prerelease": true},
+	}
+	tests.AssertDeepEqualValues(t, expected, payloads)
+}
+
+func TestGitHubPublishError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"message":"Validation Failed"}`))
+	}))
+	defer server.Close()
+
+	gitHub, err := release.NewGitHub(newLogger(t), server.Client(), server.URL, "owner", "project", "token")
+	tests.AssertNoError(t, err)
+
+	err = gitHub.Publish("v1.2.0", "1.2.0", "notes")
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, `error while creating github release due to: unexpected status 422: {"message":"Validation Failed"}`, err.Error())
+
+	_, err = release.NewGitHub(newLogger(t), nil, server.URL, "owner", "project", "")
+	tests.AssertError(t, err)
+	tests.AssertEqualValues(t, "token cannot be empty", err.Error())
+}
+
+func TestDefaultGitHubAPIURL(t *testing.T) {
+	tests.AssertEqualValues(t, "https://api.github.com", release.DefaultGitHubAPIURL("github.com"))
+	tests.AssertEqualValues(t, "https://github.example.com/api/v3", release.DefaultGitHubAPIURL("github.example.com"))
+}
+
+func TestGitLabPublishRetryAlreadyCreatedNoError(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"message":"Release already exists"}`))
+	}))
+	defer server.Close()
+
+	err := newGitLab(t, server).Publish("v1.2.0", "1.2.0", "notes")
+	tests.AssertNoError(t, err)
+	tests.AssertEqualValues(t, 2, attempts)
+}
+
+func TestGitHubPublishRetryAlreadyCreatedNoError(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"message":"Validation Failed","errors":[{"resource":"Release","code":"already_exists","field":"tag_name"}]}`))
+	}))
+	defer server.Close()
+
+	gitHub, err := release.NewGitHub(newLogger(t), server.Client(), server.URL, "owner", "project", "token")
+	tests.AssertNoError(t, err)
+	gitHub.SetRetries(2, time.Millisecond)
+
+	err = gitHub.Publish("v1.2.0", "1.2.0", "notes")
+	tests.AssertNoError(t, err)
+	tests.AssertEqualValues(t, 2, attempts)
+}
@@ -5,17 +5,10 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/NeowayLabs/semantic-release/src/semver"
 	"github.com/go-git/go-git/v5/plumbing/object"
 )
 
-const (
-	colorCyan   = "\033[36m"
-	colorYellow = "\033[33m"
-	colorReset  = "\033[0m"
-	colorRed    = "\033[31m"
-	colorBGRed  = "\033[41;1;37m"
-)
-
 type CommitMessageManager interface {
 	IsValidMessage(message string) bool
 }
@@ -38,16 +31,27 @@ type RepositoryVersionControl interface {
 	UpgradeRemoteRepository(newVersion string) error
 	GetCommitHistory() []*object.Commit
 	GetCommitHistoryDiff() []*object.Commit
+	GetCommitsSinceLastRelease() []*object.Commit
+	GetNextPreReleaseNumber(version, channel string) int
+	GetReleaseTag(newVersion string) string
+	GetCurrentTag() string
 }
 
 type VersionControl interface {
 	GetNewVersion(commitMessage string, currentVersion string) (string, error)
+	GetUpgradeType(commitMessage string) (string, error)
 	MustSkipVersioning(commitMessage string) bool
 }
 
 type FilesVersionControl interface {
 	UpgradeChangeLog(path, destinationPath string, chageLogInfo interface{}) error
 	UpgradeVariableInFiles(filesToUpgrade interface{}, newVersion string) error
+	GetReleaseNotes(chageLogInfo interface{}) (string, error)
+}
+
+// ReleasePublisher publishes the release of a tag in the git hosting provider. I.e.: GitLab Releases
+type ReleasePublisher interface {
+	Publish(tagName, version, description string) error
 }
 
 type ChangesInfo struct {
@@ -58,17 +62,104 @@ type ChangesInfo struct {
 	CurrentVersion string
 	NewVersion     string
 	ChangeType     string
+	Changes        []ChangeInfo
+	CurrentTag     string
+	NewTag         string
+}
+
+// ChangeInfo describes one of the commits included in a release.
+type ChangeInfo struct {
+	Hash        string
+	AuthorName  string
+	AuthorEmail string
+	Message     string
+	ChangeType  string
 }
 
+// upgradePriority ranks the upgrade types so the highest one of a release can be found.
+var upgradePriority = map[string]int{"none": 0, "patch": 1, "minor": 2, "major": 3}
+
 type Semantic struct {
 	log                   Logger
 	rootPath              string
+	changelogPath         string
 	filesToUpdateVariable interface{}
 	repoVersionControl    RepositoryVersionControl
 	versionControl        VersionControl
 	filesVersionControl   FilesVersionControl
 	commitMessageManager  CommitMessageManager
 	commitType            CommitType
+	dryRun                bool
+	preReleaseChannel     string
+	maintenanceRange      *semver.MaintenanceRange
+	releasePublisher      ReleasePublisher
+}
+
+// validateMaintenanceRange makes sure the new version is within the range of the maintenance branch, if any.
+func (s *Semantic) validateMaintenanceRange(newVersion string) error {
+	if s.maintenanceRange == nil {
+		return nil
+	}
+
+	version, err := semver.Parse(newVersion)
+	if err != nil {
+		return err
+	}
+
+	if !s.maintenanceRange.Contains(version) {
+		return fmt.Errorf("version %s is out of the range of the maintenance branch %s. Only changes within the range can be released from it", newVersion, s.maintenanceRange.String())
+	}
+
+	return nil
+}
+
+// toPreRelease turns the new version into the next pre-release of the channel, if any. I.e.: 1.4.0 into 1.4.0-beta.2
+func (s *Semantic) toPreRelease(newVersion string) string {
+	if s.preReleaseChannel == "" {
+		return newVersion
+	}
+
+	number := s.repoVersionControl.GetNextPreReleaseNumber(newVersion, s.preReleaseChannel)
+	return fmt.Sprintf("%s-%s.%d", newVersion, s.preReleaseChannel, number)
+}
+
+// getChangesSinceLastRelease returns the commits since the last release that trigger an upgrade, the most recent first.
+// It also returns the message of the commit triggering the highest upgrade, which is empty when there is none.
+func (s *Semantic) getChangesSinceLastRelease() ([]ChangeInfo, string) {
+	var changes []ChangeInfo
+	releaseMessage := ""
+	highestUpgradeType := "none"
+
+	for _, commit := range s.repoVersionControl.GetCommitsSinceLastRelease() {
+		if s.versionControl.MustSkipVersioning(commit.Message) {
+			continue
+		}
+
+		upgradeType, err := s.versionControl.GetUpgradeType(commit.Message)
+		if err != nil {
+			continue
+		}
+
+		changeType, err := s.commitType.GetCommitChangeType(commit.Message)
+		if err != nil {
+			continue
+		}
+
+		changes = append(changes, ChangeInfo{
+			Hash:        commit.Hash.String(),
+			AuthorName:  commit.Author.Name,
+			AuthorEmail: commit.Author.Email,
+			Message:     commit.Message,
+			ChangeType:  changeType,
+		})
+
+		if upgradePriority[upgradeType] > upgradePriority[highestUpgradeType] {
+			releaseMessage = commit.Message
+			highestUpgradeType = upgradeType
+		}
+	}
+
+	return changes, releaseMessage
 }
 
 func (s *Semantic) GenerateNewRelease() error {
@@ -81,34 +172,49 @@ func (s *Semantic) GenerateNewRelease() error {
 	}
 
 	if s.versionControl.MustSkipVersioning(changesInfo.Message) {
-		s.log.Info(colorCyan + "Semantic Release has been skiped by commit message tag [skip]" + colorReset)
+		s.log.Info("Semantic Release has been skiped by commit message tag [skip]")
 		return nil
 	}
 
-	newVersion, err := s.versionControl.GetNewVersion(changesInfo.Message, changesInfo.CurrentVersion)
+	// the upgrade is defined by the highest one among all commits since the last release, not only by the most recent commit
+	changes, releaseMessage := s.getChangesSinceLastRelease()
+	if releaseMessage == "" {
+		releaseMessage = changesInfo.Message
+	}
+	changesInfo.Changes = changes
+
+	newVersion, err := s.versionControl.GetNewVersion(releaseMessage, changesInfo.CurrentVersion)
 	if err != nil {
 		return errors.New("error while getting new version due to: " + err.Error())
 	}
 
+	if err := s.validateMaintenanceRange(newVersion); err != nil {
+		return err
+	}
+
+	newVersion = s.toPreRelease(newVersion)
 	changesInfo.NewVersion = newVersion
+	changesInfo.CurrentTag = s.repoVersionControl.GetCurrentTag()
+	changesInfo.NewTag = s.repoVersionControl.GetReleaseTag(newVersion)
 
-	commitChangeType, err := s.commitType.GetCommitChangeType(changesInfo.Message)
+	commitChangeType, err := s.commitType.GetCommitChangeType(releaseMessage)
 	if err != nil {
 		return fmt.Errorf("error while getting commit change type due to: %s", err.Error())
 	}
 
 	changesInfo.ChangeType = commitChangeType
 
-	s.log.Info(colorBGRed + "MOST RECENT COMMIT:" + colorReset)
+	s.log.Info("MOST RECENT COMMIT:")
 	s.log.Info("Hash: %s", changesInfo.Hash)
 	s.log.Info("Author Name: %s", changesInfo.AuthorName)
 	s.log.Info("Author Email: %s", changesInfo.AuthorEmail)
 	s.log.Info("Message: %s", changesInfo.Message)
 	s.log.Info("Current Version: %s", changesInfo.CurrentVersion)
-	s.log.Info(fmt.Sprintf("Commit change type: "+colorYellow+"%s"+colorReset, commitChangeType))
+	s.log.Info("Commit change type: %s", commitChangeType)
 	s.log.Info("New Version: %s", changesInfo.NewVersion)
+	s.log.Info("Commits since last release: %d", len(changesInfo.Changes))
 
-	if err := s.filesVersionControl.UpgradeChangeLog(fmt.Sprintf("%s/CHANGELOG.md", s.rootPath), "", changesInfo); err != nil {
+	if err := s.filesVersionControl.UpgradeChangeLog(s.changelogPath, "", changesInfo); err != nil {
 		return errors.New("error while upgrading changelog file due to: " + err.Error())
 	}
 
@@ -118,36 +224,121 @@ func (s *Semantic) GenerateNewRelease() error {
 		}
 	}
 
+	if s.dryRun {
+		s.log.Info("Dry run: skipping commit, push and tag of version %s", newVersion)
+		return nil
+	}
+
 	if err := s.repoVersionControl.UpgradeRemoteRepository(newVersion); err != nil {
 		return errors.New("error while upgrading remote repository due to: " + err.Error())
 	}
 
+	return s.publishRelease(changesInfo)
+}
+
+// publishRelease publishes the release of the tag just pushed, described by its changelog section, when a publisher is set.
+func (s *Semantic) publishRelease(changesInfo *ChangesInfo) error {
+	if s.releasePublisher == nil {
+		return nil
+	}
+
+	releaseNotes, err := s.filesVersionControl.GetReleaseNotes(changesInfo)
+	if err != nil {
+		return fmt.Errorf("error while getting release notes due to: %w", err)
+	}
+
+	tagName := changesInfo.NewTag
+	if err := s.releasePublisher.Publish(tagName, changesInfo.NewVersion, releaseNotes); err != nil {
+		return fmt.Errorf("error while publishing release %s due to: %w", tagName, err)
+	}
+
+	s.log.Info("Release %s published", tagName)
 	return nil
 }
 
+// GetNextVersion computes the version GenerateNewRelease would release without changing anything.
+// It also returns the upgrade type (major, minor, patch or none).
+// When the versioning must be skipped the current version is returned with the upgrade type none.
+func (s *Semantic) GetNextVersion() (string, string, error) {
+	message := s.repoVersionControl.GetChangeMessage()
+	currentVersion := s.repoVersionControl.GetCurrentVersion()
+
+	if s.versionControl.MustSkipVersioning(message) {
+		return currentVersion, "none", nil
+	}
+
+	_, releaseMessage := s.getChangesSinceLastRelease()
+	if releaseMessage == "" {
+		releaseMessage = message
+	}
+
+	upgradeType, err := s.versionControl.GetUpgradeType(releaseMessage)
+	if err != nil {
+		return "", "", fmt.Errorf("error while getting upgrade type due to: %s", err.Error())
+	}
+
+	newVersion, err := s.versionControl.GetNewVersion(releaseMessage, currentVersion)
+	if err != nil {
+		return "", "", errors.New("error while getting new version due to: " + err.Error())
+	}
+
+	if err := s.validateMaintenanceRange(newVersion); err != nil {
+		return "", "", err
+	}
+
+	return s.toPreRelease(newVersion), upgradeType, nil
+}
+
 func (s *Semantic) CommitLint() error {
 	commitHistoryDiff := s.repoVersionControl.GetCommitHistoryDiff()
 	areThereWrongCommits := false
 	for _, commit := range commitHistoryDiff {
 		if !s.commitMessageManager.IsValidMessage(commit.Message) {
-			s.log.Error(colorRed+"commit message "+colorYellow+"( %s )"+colorRed+" does not meet semantic-release pattern "+colorYellow+"(type(scope?): message here.)"+colorReset, strings.TrimSuffix(commit.Message, "\n"))
+			s.log.Error("commit message ( %s ) does not meet semantic-release pattern (type(scope?): message here.)", strings.TrimSuffix(commit.Message, "\n"))
 			areThereWrongCommits = true
 		}
 	}
 	if areThereWrongCommits {
-		s.log.Error(colorRed + "You can use " + colorBGRed + "git rebase -i HEAD~<number of commits to fix>" + colorReset + colorRed + " and edit the commit list with reword before each commit message." + colorReset)
+		s.log.Error("You can use git rebase -i HEAD~<number of commits to fix> and edit the commit list with reword before each commit message.")
 		return errors.New("commit messages dos not meet semantic-release pattern")
 	}
 
-	s.log.Info(colorRed + "Remember to adapt the " + colorBGRed + "MERGE REQUEST TITLE" + colorReset + colorRed + " or the " + colorBGRed + "MERGE COMMIT MESSAGE" + colorReset + colorRed + " to semantic-release standards so it can properlly generate the new tag release." + colorReset)
+	s.log.Info("Remember to adapt the MERGE REQUEST TITLE or the MERGE COMMIT MESSAGE to semantic-release standards so it can properlly generate the new tag release.")
 
 	return nil
 }
 
+// SetChangelogPath changes the file the release notes are appended to. It defaults to CHANGELOG.md at the root path.
+func (s *Semantic) SetChangelogPath(changelogPath string) {
+	s.changelogPath = changelogPath
+}
+
+// SetDryRun makes GenerateNewRelease stop before committing, pushing and tagging the new release.
+func (s *Semantic) SetDryRun(dryRun bool) {
+	s.dryRun = dryRun
+}
+
+// SetPreReleaseChannel makes the releases pre-releases of the given channel. I.e.: beta releases 1.4.0-beta.1, 1.4.0-beta.2 and so on.
+// An empty channel releases stable versions.
+func (s *Semantic) SetPreReleaseChannel(channel string) {
+	s.preReleaseChannel = channel
+}
+
+// SetMaintenanceRange makes releases fail when the new version is out of the range of a maintenance branch. I.e.: a feat on 1.2.x
+func (s *Semantic) SetMaintenanceRange(maintenanceRange *semver.MaintenanceRange) {
+	s.maintenanceRange = maintenanceRange
+}
+
+// SetReleasePublisher makes GenerateNewRelease publish the release in the git hosting provider after pushing the tag.
+func (s *Semantic) SetReleasePublisher(releasePublisher ReleasePublisher) {
+	s.releasePublisher = releasePublisher
+}
+
 func New(log Logger, rootPath string, filesToUpdateVariable interface{}, repoVersionControl RepositoryVersionControl, filesVersionControl FilesVersionControl, versionControl VersionControl, commitMessageManager CommitMessageManager, commitType CommitType) *Semantic {
 	return &Semantic{
 		log:                   log,
 		rootPath:              rootPath,
+		changelogPath:         fmt.Sprintf("%s/CHANGELOG.md", rootPath),
 		filesToUpdateVariable: filesToUpdateVariable,
 		repoVersionControl:    repoVersionControl,
 		filesVersionControl:   filesVersionControl,
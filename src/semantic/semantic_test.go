@@ -13,6 +13,7 @@ import (
 	committype "github.com/NeowayLabs/semantic-release/src/commit-type"
 	"github.com/NeowayLabs/semantic-release/src/log"
 	"github.com/NeowayLabs/semantic-release/src/semantic"
+	"github.com/NeowayLabs/semantic-release/src/semver"
 	"github.com/NeowayLabs/semantic-release/src/tests"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
@@ -28,6 +29,8 @@ type RepositoryVersionControlMock struct {
 	errUpgradeRemoteRepo error
 	commitHistory        []*object.Commit
 	commitHistoryDiff    []*object.Commit
+	commitsSinceRelease  []*object.Commit
+	nextPreReleaseNumber int
 }
 
 func (r *RepositoryVersionControlMock) GetChangeHash() string {
@@ -50,6 +53,14 @@ func (r *RepositoryVersionControlMock) UpgradeRemoteRepository(newVersion string
 	return r.errUpgradeRemoteRepo
 }
 
+func (r *RepositoryVersionControlMock) GetReleaseTag(newVersion string) string {
+	return "v" + newVersion
+}
+
+func (r *RepositoryVersionControlMock) GetCurrentTag() string {
+	return "v" + r.currentVersion
+}
+
 func (r *RepositoryVersionControlMock) GetCommitHistory() []*object.Commit {
 	return r.commitHistory
 }
@@ -58,12 +69,24 @@ func (r *RepositoryVersionControlMock) GetCommitHistoryDiff() []*object.Commit {
 	return r.commitHistoryDiff
 }
 
+func (r *RepositoryVersionControlMock) GetCommitsSinceLastRelease() []*object.Commit {
+	return r.commitsSinceRelease
+}
+
+func (r *RepositoryVersionControlMock) GetNextPreReleaseNumber(version, channel string) int {
+	return r.nextPreReleaseNumber
+}
+
 type VersionControlMock struct {
 	newVersion          string
 	errGetNewVersion    error
+	upgradeType         string
+	errGetUpgradeType   error
 	mustSkip            bool
 	commitChangeType    string
 	errCommitChangeType error
+	upgradeTypes        map[string]string
+	newVersionMessage   string
 }
 
 func (v *VersionControlMock) GetCommitChangeType(commitMessage string) (string, error) {
@@ -71,9 +94,17 @@ func (v *VersionControlMock) GetCommitChangeType(commitMessage string) (string,
 }
 
 func (v *VersionControlMock) GetNewVersion(commitMessage string, currentVersion string) (string, error) {
+	v.newVersionMessage = commitMessage
 	return v.newVersion, v.errGetNewVersion
 }
 
+func (v *VersionControlMock) GetUpgradeType(commitMessage string) (string, error) {
+	if upgradeType, ok := v.upgradeTypes[commitMessage]; ok {
+		return upgradeType, nil
+	}
+	return v.upgradeType, v.errGetUpgradeType
+}
+
 func (v *VersionControlMock) MustSkipVersioning(commitMessage string) bool {
 	return v.mustSkip
 }
@@ -81,15 +112,37 @@ func (v *VersionControlMock) MustSkipVersioning(commitMessage string) bool {
 type FilesVersionControlMock struct {
 	errUpgradeChangeLog       error
 	errUpgradeVariableInFiles error
+	changeLogInfo             interface{}
+	releaseNotes              string
+	errGetReleaseNotes        error
 }
 
 func (f *FilesVersionControlMock) UpgradeChangeLog(path, destinationPath string, chageLogInfo interface{}) error {
+	f.changeLogInfo = chageLogInfo
 	return f.errUpgradeChangeLog
 }
 func (f *FilesVersionControlMock) UpgradeVariableInFiles(filesToUpgrade interface{}, newVersion string) error {
 	return f.errUpgradeVariableInFiles
 }
 
+func (f *FilesVersionControlMock) GetReleaseNotes(chageLogInfo interface{}) (string, error) {
+	return f.releaseNotes, f.errGetReleaseNotes
+}
+
+type ReleasePublisherMock struct {
+	tagName     string
+	version     string
+	description string
+	errPublish  error
+}
+
+func (r *ReleasePublisherMock) Publish(tagName, version, description string) error {
+	r.tagName = tagName
+	r.version = version
+	r.description = description
+	return r.errPublish
+}
+
 type fixture struct {
 	rootPath              string
 	filesToUpdateVariable interface{}
@@ -327,3 +380,219 @@ func TestCommitLintSucess(t *testing.T) {
 	actualErr := semanticService.CommitLint()
 	tests.AssertNoError(t, actualErr)
 }
+
+func TestGenerateNewReleaseDryRunSkipsUpgradeRemoteRepository(t *testing.T) {
+	f := setup()
+	f.repoVersionMock.currentChangesInfo = f.GetValidMessageChangesInfo()
+	f.repoVersionMock.errUpgradeRemoteRepo = errors.New("upgrade remote repository error")
+	f.filesToUpdateVariable = f.GetValidUpgradeFilesInfo()
+
+	semanticService := f.NewSemantic()
+	semanticService.SetDryRun(true)
+	actualErr := semanticService.GenerateNewRelease()
+	tests.AssertNoError(t, actualErr)
+}
+
+func TestGetNextVersionSuccess(t *testing.T) {
+	f := setup()
+	f.repoVersionMock.currentChangesInfo = f.GetValidMessageChangesInfo()
+	f.repoVersionMock.currentVersion = "1.0.0"
+	f.versionControlMock.newVersion = "1.0.1"
+	f.versionControlMock.upgradeType = "patch"
+
+	semanticService := f.NewSemantic()
+	actualVersion, actualUpgradeType, actualErr := semanticService.GetNextVersion()
+	tests.AssertNoError(t, actualErr)
+	tests.AssertEqualValues(t, "1.0.1", actualVersion)
+	tests.AssertEqualValues(t, "patch", actualUpgradeType)
+}
+
+func TestGetNextVersionMustSkip(t *testing.T) {
+	f := setup()
+	f.repoVersionMock.currentVersion = "1.0.0"
+	f.versionControlMock.mustSkip = true
+	f.versionControlMock.upgradeType = "none"
+	f.versionControlMock.errGetNewVersion = errors.New("get new version error")
+
+	semanticService := f.NewSemantic()
+	actualVersion, actualUpgradeType, actualErr := semanticService.GetNextVersion()
+	tests.AssertNoError(t, actualErr)
+	tests.AssertEqualValues(t, "1.0.0", actualVersion)
+	tests.AssertEqualValues(t, "none", actualUpgradeType)
+}
+
+func TestGetNextVersionGetUpgradeTypeError(t *testing.T) {
+	f := setup()
+	f.versionControlMock.errGetUpgradeType = errors.New("change type not found")
+
+	semanticService := f.NewSemantic()
+	_, _, actualErr := semanticService.GetNextVersion()
+	tests.AssertError(t, actualErr)
+	tests.AssertEqualValues(t, "error while getting upgrade type due to: change type not found", actualErr.Error())
+}
+
+func TestGetNextVersionGetNewVersionError(t *testing.T) {
+	f := setup()
+	f.versionControlMock.upgradeType = "patch"
+	f.versionControlMock.errGetNewVersion = errors.New("get new version error")
+
+	semanticService := f.NewSemantic()
+	_, _, actualErr := semanticService.GetNextVersion()
+	tests.AssertError(t, actualErr)
+	tests.AssertEqualValues(t, "error while getting new version due to: get new version error", actualErr.Error())
+}
+
+func TestGenerateNewReleaseUsesHighestUpgradeSinceLastRelease(t *testing.T) {
+	f := setup()
+	f.repoVersionMock.currentChangesInfo = f.GetValidMessageChangesInfo()
+	f.repoVersionMock.commitsSinceRelease = f.GetCommitHistoryWithRightMessagesPattern()
+	f.versionControlMock.upgradeTypes = map[string]string{
+		"fix(scope): this is a fix correct commit message.":      "patch",
+		"feat(scope): this is a feature correct commit message.": "minor",
+	}
+	f.versionControlMock.newVersion = "1.1.0"
+
+	semanticService := f.NewSemantic()
+	actualErr := semanticService.GenerateNewRelease()
+	tests.AssertNoError(t, actualErr)
+	tests.AssertEqualValues(t, "feat(scope): this is a feature correct commit message.", f.versionControlMock.newVersionMessage)
+
+	changesInfo, ok := f.filesVersionMock.changeLogInfo.(*semantic.ChangesInfo)
+	tests.AssertEqualValues(t, true, ok)
+	tests.AssertEqualValues(t, 2, len(changesInfo.Changes))
+	tests.AssertEqualValues(t, "feat", changesInfo.ChangeType)
+}
+
+func TestGenerateNewReleaseIgnoresInvalidCommitsSinceLastRelease(t *testing.T) {
+	f := setup()
+	f.repoVersionMock.currentChangesInfo = f.GetValidMessageChangesInfo()
+	f.repoVersionMock.commitsSinceRelease = append(f.GetCommitHistoryWithWrongMessagesPattern(), f.GetCommitHistoryWithRightMessagesPattern()[0])
+	f.versionControlMock.upgradeTypes = map[string]string{"fix(scope): this is a fix correct commit message.": "patch"}
+	f.versionControlMock.newVersion = "1.0.1"
+
+	semanticService := f.NewSemantic()
+	actualErr := semanticService.GenerateNewRelease()
+	tests.AssertNoError(t, actualErr)
+
+	changesInfo := f.filesVersionMock.changeLogInfo.(*semantic.ChangesInfo)
+	tests.AssertEqualValues(t, 1, len(changesInfo.Changes))
+	tests.AssertEqualValues(t, "fix(scope): this is a fix correct commit message.", changesInfo.Changes[0].Message)
+}
+
+func TestGetNextVersionUsesHighestUpgradeSinceLastRelease(t *testing.T) {
+	f := setup()
+	f.repoVersionMock.currentChangesInfo = f.GetValidMessageChangesInfo()
+	f.repoVersionMock.currentVersion = "1.0.0"
+	f.repoVersionMock.commitsSinceRelease = f.GetCommitHistoryWithRightMessagesPattern()
+	f.versionControlMock.upgradeTypes = map[string]string{
+		"fix(scope): this is a fix correct commit message.":      "patch",
+		"feat(scope): this is a feature correct commit message.": "minor",
+	}
+	f.versionControlMock.newVersion = "1.1.0"
+
+	semanticService := f.NewSemantic()
+	actualVersion, actualUpgradeType, actualErr := semanticService.GetNextVersion()
+	tests.AssertNoError(t, actualErr)
+	tests.AssertEqualValues(t, "1.1.0", actualVersion)
+	tests.AssertEqualValues(t, "minor", actualUpgradeType)
+}
+
+func TestGenerateNewReleasePreReleaseChannel(t *testing.T) {
+	f := setup()
+	f.repoVersionMock.currentChangesInfo = f.GetValidMessageChangesInfo()
+	f.repoVersionMock.nextPreReleaseNumber = 3
+	f.versionControlMock.newVersion = "1.4.0"
+
+	semanticService := f.NewSemantic()
+	semanticService.SetPreReleaseChannel("beta")
+	actualErr := semanticService.GenerateNewRelease()
+	tests.AssertNoError(t, actualErr)
+
+	changesInfo := f.filesVersionMock.changeLogInfo.(*semantic.ChangesInfo)
+	tests.AssertEqualValues(t, "1.4.0-beta.3", changesInfo.NewVersion)
+}
+
+func TestGetNextVersionPreReleaseChannel(t *testing.T) {
+	f := setup()
+	f.repoVersionMock.currentChangesInfo = f.GetValidMessageChangesInfo()
+	f.repoVersionMock.nextPreReleaseNumber = 1
+	f.versionControlMock.newVersion = "1.4.0"
+	f.versionControlMock.upgradeType = "minor"
+
+	semanticService := f.NewSemantic()
+	semanticService.SetPreReleaseChannel("next")
+	actualVersion, actualUpgradeType, actualErr := semanticService.GetNextVersion()
+	tests.AssertNoError(t, actualErr)
+	tests.AssertEqualValues(t, "1.4.0-next.1", actualVersion)
+	tests.AssertEqualValues(t, "minor", actualUpgradeType)
+}
+
+func TestGenerateNewReleaseOutOfMaintenanceRangeError(t *testing.T) {
+	f := setup()
+	f.repoVersionMock.currentChangesInfo = f.GetValidMessageChangesInfo()
+	f.versionControlMock.newVersion = "1.3.0"
+
+	maintenanceRange, _ := semver.ParseMaintenanceRange("1.2.x")
+	semanticService := f.NewSemantic()
+	semanticService.SetMaintenanceRange(maintenanceRange)
+	actualErr := semanticService.GenerateNewRelease()
+	tests.AssertError(t, actualErr)
+	tests.AssertEqualValues(t, "version 1.3.0 is out of the range of the maintenance branch 1.2.x. Only changes within the range can be released from it", actualErr.Error())
+}
+
+func TestGetNextVersionWithinMaintenanceRange(t *testing.T) {
+	f := setup()
+	f.repoVersionMock.currentChangesInfo = f.GetValidMessageChangesInfo()
+	f.versionControlMock.newVersion = "1.2.4"
+	f.versionControlMock.upgradeType = "patch"
+
+	maintenanceRange, _ := semver.ParseMaintenanceRange("1.2.x")
+	semanticService := f.NewSemantic()
+	semanticService.SetMaintenanceRange(maintenanceRange)
+	actualVersion, _, actualErr := semanticService.GetNextVersion()
+	tests.AssertNoError(t, actualErr)
+	tests.AssertEqualValues(t, "1.2.4", actualVersion)
+}
+
+func TestGenerateNewReleasePublishRelease(t *testing.T) {
+	f := setup()
+	f.repoVersionMock.currentChangesInfo = f.GetValidMessageChangesInfo()
+	f.versionControlMock.newVersion = "1.0.1"
+	f.filesVersionMock.releaseNotes = "## v1.0.1\n- fix - Any Message\n"
+	releasePublisher := &ReleasePublisherMock{}
+
+	semanticService := f.NewSemantic()
+	semanticService.SetReleasePublisher(releasePublisher)
+	actualErr := semanticService.GenerateNewRelease()
+	tests.AssertNoError(t, actualErr)
+	tests.AssertEqualValues(t, "v1.0.1", releasePublisher.tagName)
+	tests.AssertEqualValues(t, "1.0.1", releasePublisher.version)
+	tests.AssertEqualValues(t, "## v1.0.1\n- fix - Any Message\n", releasePublisher.description)
+}
+
+func TestGenerateNewReleasePublishReleaseError(t *testing.T) {
+	f := setup()
+	f.repoVersionMock.currentChangesInfo = f.GetValidMessageChangesInfo()
+	f.versionControlMock.newVersion = "1.0.1"
+	releasePublisher := &ReleasePublisherMock{errPublish: errors.New("unexpected status 403")}
+
+	semanticService := f.NewSemantic()
+	semanticService.SetReleasePublisher(releasePublisher)
+	actualErr := semanticService.GenerateNewRelease()
+	tests.AssertError(t, actualErr)
+	tests.AssertEqualValues(t, "error while publishing release v1.0.1 due to: unexpected status 403", actualErr.Error())
+}
+
+func TestGenerateNewReleaseDryRunSkipsPublishRelease(t *testing.T) {
+	f := setup()
+	f.repoVersionMock.currentChangesInfo = f.GetValidMessageChangesInfo()
+	f.versionControlMock.newVersion = "1.0.1"
+	releasePublisher := &ReleasePublisherMock{}
+
+	semanticService := f.NewSemantic()
+	semanticService.SetDryRun(true)
+	semanticService.SetReleasePublisher(releasePublisher)
+	actualErr := semanticService.GenerateNewRelease()
+	tests.AssertNoError(t, actualErr)
+	tests.AssertEqualValues(t, "", releasePublisher.tagName)
+}
@@ -0,0 +1,50 @@
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// maintenanceBranchPattern matches the maintenance branches. I.e.: 1.x or 1.2.x
+var maintenanceBranchPattern = regexp.MustCompile(`^(\d+)\.(?:(\d+)\.)?x$`)
+
+// MaintenanceRange is the range of versions released from a maintenance branch.
+// I.e.: 1.x releases 1.y.z versions and 1.2.x releases 1.2.z versions.
+type MaintenanceRange struct {
+	Major int
+	// Minor is -1 when any minor version is within the range.
+	Minor int
+}
+
+// ParseMaintenanceRange reads the range of a maintenance branch. It returns false when the branch is not a maintenance one.
+func ParseMaintenanceRange(branch string) (*MaintenanceRange, bool) {
+	found := maintenanceBranchPattern.FindStringSubmatch(branch)
+	if found == nil {
+		return nil, false
+	}
+
+	maintenanceRange := &MaintenanceRange{Minor: -1}
+	maintenanceRange.Major, _ = strconv.Atoi(found[1])
+	if found[2] != "" {
+		maintenanceRange.Minor, _ = strconv.Atoi(found[2])
+	}
+
+	return maintenanceRange, true
+}
+
+// Contains returns true when the version is within the range.
+func (r *MaintenanceRange) Contains(version *Version) bool {
+	if version.Major != r.Major {
+		return false
+	}
+	return r.Minor == -1 || version.Minor == r.Minor
+}
+
+// String formats the range. I.e.: 1.x or 1.2.x
+func (r *MaintenanceRange) String() string {
+	if r.Minor == -1 {
+		return fmt.Sprintf("%d.x", r.Major)
+	}
+	return fmt.Sprintf("%d.%d.x", r.Major, r.Minor)
+}
@@ -0,0 +1,181 @@
+package semver
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var identifierPattern = regexp.MustCompile(`^[0-9A-Za-z-]+$`)
+
+// Version is a semantic version following the SemVer 2.0 specification.
+// See https://semver.org/spec/v2.0.0.html
+// I.e.: 1.2.3-rc.1+build.5 has Major 1, Minor 2, Patch 3, PreRelease [rc 1] and Build [build 5]
+type Version struct {
+	Major      int
+	Minor      int
+	Patch      int
+	PreRelease []string
+	Build      []string
+}
+
+func isNumeric(identifier string) bool {
+	for _, r := range identifier {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return identifier != ""
+}
+
+func hasLeadingZero(identifier string) bool {
+	return len(identifier) > 1 && identifier[0] == '0'
+}
+
+func parseNumber(number string) (int, error) {
+	if !isNumeric(number) {
+		return 0, fmt.Errorf("could not convert %v to int", number)
+	}
+
+	if hasLeadingZero(number) {
+		return 0, fmt.Errorf("version number %s must not have leading zeros", number)
+	}
+
+	return strconv.Atoi(number)
+}
+
+func parseIdentifiers(identifiers string, numericLeadingZero bool) ([]string, error) {
+	result := strings.Split(identifiers, ".")
+	for _, identifier := range result {
+		if !identifierPattern.MatchString(identifier) {
+			return nil, fmt.Errorf("identifier %q must contain only alphanumerics and hyphens", identifier)
+		}
+
+		if !numericLeadingZero && isNumeric(identifier) && hasLeadingZero(identifier) {
+			return nil, fmt.Errorf("numeric identifier %s must not have leading zeros", identifier)
+		}
+	}
+	return result, nil
+}
+
+// Parse reads a semantic version. I.e.: 1.2.3, 2.0.0-beta.3 or 1.2.3+build.5
+func Parse(version string) (*Version, error) {
+	var result Version
+	var err error
+
+	if index := strings.Index(version, "+"); index != -1 {
+		if result.Build, err = parseIdentifiers(version[index+1:], true); err != nil {
+			return nil, fmt.Errorf("invalid build metadata of version %s: %w", version, err)
+		}
+		version = version[:index]
+	}
+
+	if index := strings.Index(version, "-"); index != -1 {
+		if result.PreRelease, err = parseIdentifiers(version[index+1:], false); err != nil {
+			return nil, fmt.Errorf("invalid pre-release of version %s: %w", version, err)
+		}
+		version = version[:index]
+	}
+
+	segments := strings.Split(version, ".")
+	if len(segments) != 3 {
+		return nil, errors.New("version must follow the pattern major.minor.patch. I.e.: 1.0.0")
+	}
+
+	for i, number := range []*int{&result.Major, &result.Minor, &result.Patch} {
+		if *number, err = parseNumber(segments[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return &result, nil
+}
+
+// String formats the version. I.e.: 1.2.3-rc.1+build.5
+func (v *Version) String() string {
+	version := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+
+	if len(v.PreRelease) > 0 {
+		version += "-" + strings.Join(v.PreRelease, ".")
+	}
+
+	if len(v.Build) > 0 {
+		version += "+" + strings.Join(v.Build, ".")
+	}
+
+	return version
+}
+
+// IsPreRelease returns true when the version has pre-release identifiers. I.e.: 2.0.0-beta.3
+func (v *Version) IsPreRelease() bool {
+	return len(v.PreRelease) > 0
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compareIdentifier compares pre-release identifiers. Numeric identifiers are compared numerically and
+// have lower precedence than alphanumeric ones, which are compared lexically.
+func compareIdentifier(a, b string) int {
+	aNumeric, bNumeric := isNumeric(a), isNumeric(b)
+
+	switch {
+	case aNumeric && bNumeric:
+		if len(a) != len(b) {
+			return compareInt(len(a), len(b))
+		}
+		return strings.Compare(a, b)
+	case aNumeric:
+		return -1
+	case bNumeric:
+		return 1
+	}
+
+	return strings.Compare(a, b)
+}
+
+// Compare returns -1, 0 or 1 when the version has lower, equal or higher precedence than other.
+// Build metadata is ignored. I.e.: 1.0.0-alpha < 1.0.0-alpha.1 < 1.0.0-beta < 1.0.0-rc.1 < 1.0.0
+func (v *Version) Compare(other *Version) int {
+	for _, result := range []int{
+		compareInt(v.Major, other.Major),
+		compareInt(v.Minor, other.Minor),
+		compareInt(v.Patch, other.Patch),
+	} {
+		if result != 0 {
+			return result
+		}
+	}
+
+	// a pre-release version has lower precedence than the associated normal version
+	switch {
+	case !v.IsPreRelease() && !other.IsPreRelease():
+		return 0
+	case !v.IsPreRelease():
+		return 1
+	case !other.IsPreRelease():
+		return -1
+	}
+
+	for i := 0; i < len(v.PreRelease) && i < len(other.PreRelease); i++ {
+		if result := compareIdentifier(v.PreRelease[i], other.PreRelease[i]); result != 0 {
+			return result
+		}
+	}
+
+	return compareInt(len(v.PreRelease), len(other.PreRelease))
+}
+
+// IsGreaterThan returns true when the version has higher precedence than other.
+func (v *Version) IsGreaterThan(other *Version) bool {
+	return v.Compare(other) > 0
+}
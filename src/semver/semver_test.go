@@ -0,0 +1,99 @@
+//go:build unit
+// +build unit
+
+package semver_test
+
+import (
+	"testing"
+
+	"github.com/NeowayLabs/semantic-release/src/semver"
+	"github.com/NeowayLabs/semantic-release/src/tests"
+)
+
+func TestParseSuccess(t *testing.T) {
+	version, err := semver.Parse("1.2.3-rc.1+build.05")
+	tests.AssertNoError(t, err)
+	tests.AssertDeepEqualValues(t, &semver.Version{Major: 1, Minor: 2, Patch: 3, PreRelease: []string{"rc", "1"}, Build: []string{"build", "05"}}, version)
+	tests.AssertEqualValues(t, "1.2.3-rc.1+build.05", version.String())
+	tests.AssertTrue(t, version.IsPreRelease())
+
+	version, err = semver.Parse("10.20.30")
+	tests.AssertNoError(t, err)
+	tests.AssertEqualValues(t, "10.20.30", version.String())
+	tests.AssertFalse(t, version.IsPreRelease())
+
+	version, err = semver.Parse("1.0.0-x-y.7z.92")
+	tests.AssertNoError(t, err)
+	tests.AssertDeepEqualValues(t, []string{"x-y", "7z", "92"}, version.PreRelease)
+}
+
+func TestParseError(t *testing.T) {
+	errorsByVersion := map[string]string{
+		"1.0":           "version must follow the pattern major.minor.patch. I.e.: 1.0.0",
+		"1.0.0.0":       "version must follow the pattern major.minor.patch. I.e.: 1.0.0",
+		"1.0.a":         "could not convert a to int",
+		"01.0.0":        "version number 01 must not have leading zeros",
+		"1.0.0-rc.01":   "invalid pre-release of version 1.0.0-rc.01: numeric identifier 01 must not have leading zeros",
+		"1.0.0-rc..1":   "invalid pre-release of version 1.0.0-rc..1: identifier \"\" must contain only alphanumerics and hyphens",
+		"1.0.0+build_1": "invalid build metadata of version 1.0.0+build_1: identifier \"build_1\" must contain only alphanumerics and hyphens",
+	}
+
+	for version, expected := range errorsByVersion {
+		_, err := semver.Parse(version)
+		tests.AssertError(t, err)
+		if err != nil {
+			tests.AssertEqualValues(t, expected, err.Error())
+		}
+	}
+}
+
+func TestCompareSuccess(t *testing.T) {
+	// ordered by precedence as in https://semver.org/spec/v2.0.0.html#spec-item-11
+	ordered := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.1.0", "2.0.0-beta.3", "2.0.0"}
+
+	for i := range ordered {
+		for j := range ordered {
+			a, err := semver.Parse(ordered[i])
+			tests.AssertNoError(t, err)
+			b, err := semver.Parse(ordered[j])
+			tests.AssertNoError(t, err)
+
+			expected := 0
+			if i < j {
+				expected = -1
+			} else if i > j {
+				expected = 1
+			}
+			tests.AssertEqualValues(t, expected, a.Compare(b))
+		}
+	}
+}
+
+func TestCompareIgnoresBuildMetadata(t *testing.T) {
+	a, err := semver.Parse("1.0.0+build.1")
+	tests.AssertNoError(t, err)
+	b, err := semver.Parse("1.0.0+build.2")
+	tests.AssertNoError(t, err)
+
+	tests.AssertEqualValues(t, 0, a.Compare(b))
+	tests.AssertFalse(t, a.IsGreaterThan(b))
+}
+
+func TestParseMaintenanceRangeSuccess(t *testing.T) {
+	maintenanceRange, ok := semver.ParseMaintenanceRange("1.x")
+	tests.AssertTrue(t, ok)
+	tests.AssertEqualValues(t, "1.x", maintenanceRange.String())
+	tests.AssertTrue(t, maintenanceRange.Contains(&semver.Version{Major: 1, Minor: 5, Patch: 2}))
+	tests.AssertFalse(t, maintenanceRange.Contains(&semver.Version{Major: 2}))
+
+	maintenanceRange, ok = semver.ParseMaintenanceRange("1.2.x")
+	tests.AssertTrue(t, ok)
+	tests.AssertEqualValues(t, "1.2.x", maintenanceRange.String())
+	tests.AssertTrue(t, maintenanceRange.Contains(&semver.Version{Major: 1, Minor: 2, Patch: 4}))
+	tests.AssertFalse(t, maintenanceRange.Contains(&semver.Version{Major: 1, Minor: 3}))
+
+	for _, branch := range []string{"main", "develop", "1.2.3", "x", "1.x.x", "release/1.x"} {
+		_, ok = semver.ParseMaintenanceRange(branch)
+		tests.AssertFalse(t, ok)
+	}
+}
@@ -0,0 +1,58 @@
+package style
+
+import "os"
+
+// ANSI escape sequences used to style the terminal output. They are empty strings when colors are disabled,
+// so they can always be concatenated to messages. I.e.: style.Yellow + "message" + style.Reset
+var (
+	Cyan   string
+	Green  string
+	Red    string
+	Yellow string
+	BGRed  string
+	Reset  string
+)
+
+func init() {
+	SetEnabled(true)
+}
+
+// SetEnabled turns the colors on or off.
+func SetEnabled(enabled bool) {
+	if !enabled {
+		Cyan, Green, Red, Yellow, BGRed, Reset = "", "", "", "", "", ""
+		return
+	}
+
+	Cyan = "\033[36m"
+	Green = "\033[32m"
+	Red = "\033[31m"
+	Yellow = "\033[33m"
+	BGRed = "\033[41;1;37m"
+	Reset = "\033[0m"
+}
+
+// Enabled returns true when the output is colored.
+func Enabled() bool {
+	return Reset != ""
+}
+
+// ShouldColor returns false when noColor is set, when the NO_COLOR environment variable is set or when the
+// output is not a terminal. See https://no-color.org
+func ShouldColor(noColor bool, getenv func(key string) string, isTerminal bool) bool {
+	return !noColor && getenv("NO_COLOR") == "" && isTerminal
+}
+
+// IsTerminal returns true when file is a terminal rather than a pipe or a regular file.
+func IsTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Init turns the colors off when noColor is set, when NO_COLOR is set or when stdout is not a terminal.
+func Init(noColor bool) {
+	SetEnabled(ShouldColor(noColor, os.Getenv, IsTerminal(os.Stdout)))
+}
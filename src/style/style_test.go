@@ -0,0 +1,38 @@
+//go:build unit
+// +build unit
+
+package style_test
+
+import (
+	"testing"
+
+	"github.com/NeowayLabs/semantic-release/src/style"
+	"github.com/NeowayLabs/semantic-release/src/tests"
+)
+
+func TestShouldColor(t *testing.T) {
+	noEnvironment := func(key string) string { return "" }
+	noColorEnvironment := func(key string) string {
+		if key == "NO_COLOR" {
+			return "1"
+		}
+		return ""
+	}
+
+	tests.AssertTrue(t, style.ShouldColor(false, noEnvironment, true))
+	tests.AssertFalse(t, style.ShouldColor(true, noEnvironment, true))
+	tests.AssertFalse(t, style.ShouldColor(false, noColorEnvironment, true))
+	tests.AssertFalse(t, style.ShouldColor(false, noEnvironment, false))
+}
+
+func TestSetEnabled(t *testing.T) {
+	defer style.SetEnabled(true)
+
+	style.SetEnabled(false)
+	tests.AssertFalse(t, style.Enabled())
+	tests.AssertEqualValues(t, "message", style.Yellow+"message"+style.Reset)
+
+	style.SetEnabled(true)
+	tests.AssertTrue(t, style.Enabled())
+	tests.AssertEqualValues(t, "\033[33mmessage\033[0m", style.Yellow+"message"+style.Reset)
+}
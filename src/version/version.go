@@ -1,18 +1,18 @@
 package version
 
 import (
-	"errors"
 	"fmt"
-	"strconv"
 	"strings"
+
+	committype "github.com/NeowayLabs/semantic-release/src/commit-type"
+	"github.com/NeowayLabs/semantic-release/src/semver"
 )
 
 const (
-	major       = "MAJOR"
-	minor       = "MINOR"
-	patch       = "PATCH"
-	colorYellow = "\033[33m"
-	colorReset  = "\033[0m"
+	major = "MAJOR"
+	minor = "MINOR"
+	patch = "PATCH"
+	none  = "NONE"
 )
 
 type Logger interface {
@@ -29,6 +29,7 @@ type CommitType interface {
 	GetPatchUpgrade() []string
 	GetSkipVersioning() []string
 	GetCommitChangeType(commitMessage string) (string, error)
+	ParseCommit(commitMessage string) (*committype.ParsedCommit, error)
 }
 
 type VersionControl struct {
@@ -37,45 +38,6 @@ type VersionControl struct {
 	commitType       CommitType
 }
 
-// splitVersionMajorMinorPatch get a string version, split it and return a map of int values
-// Args:
-//
-//	version (string): Version to be splited. I.e: 2.1.1
-//
-// Returns:
-//
-//			Success:
-//			It returns a map of int values
-//	     I.e.: map[MAJOR:2 MINOR:1 PATCH:1]
-//
-//			Otherwise:
-//				error
-func (v *VersionControl) splitVersionMajorMinorPatch(version string) (map[string]int, error) {
-	splitedVersion := strings.Split(version, ".")
-
-	if len(splitedVersion) < 3 {
-		return nil, errors.New("version must follow the pattern major.minor.patch. I.e.: 1.0.0")
-	}
-
-	versionMap := make(map[string]string)
-	versionMap[major] = splitedVersion[0]
-	versionMap[minor] = splitedVersion[1]
-	versionMap[patch] = splitedVersion[2]
-
-	resultMap := make(map[string]int)
-	for key, version := range versionMap {
-		versionInt, err := strconv.Atoi(version)
-		if err != nil {
-			v.log.Error("could not convert %v to int", version)
-			return nil, fmt.Errorf("could not convert %v to int", version)
-
-		}
-		resultMap[key] = versionInt
-	}
-
-	return resultMap, nil
-}
-
 // getUpgradeType defines where to update the current version
 // MAJOR.MINOR.PATCH. I.e: 2.1.1
 // Args:
@@ -99,13 +61,38 @@ func (v *VersionControl) getUpgradeType(commitChangeType string) (string, error)
 	return "", fmt.Errorf("%s is an invalid upgrade change type", commitChangeType)
 }
 
+// getCommitUpgradeType defines where to update the current version from a commit message.
+// Breaking changes, marked with "!" after the type or scope or with a BREAKING CHANGE footer, always upgrade the MAJOR version.
+// Args:
+//
+//	commitMessage (string): The commit message.
+//
+// Returns:
+//
+//	MAJOR, MINOR or PATCH. Otherwise, it returns an error
+func (v *VersionControl) getCommitUpgradeType(commitMessage string) (string, error) {
+	parsedCommit, err := v.commitType.ParseCommit(commitMessage)
+	if err != nil {
+		return "", fmt.Errorf("error while finding commit change type within commit message due to: %w", err)
+	}
+
+	if parsedCommit.Breaking {
+		return major, nil
+	}
+
+	upgradeType, err := v.getUpgradeType(parsedCommit.Type)
+	if err != nil {
+		return "", fmt.Errorf("error while getting upgrade type due to: %w", err)
+	}
+
+	return upgradeType, nil
+}
+
 // upgradeVersion upgrade the current version based on the upgradeType.
 // Args:
 //
 //	upgradeType (string): MAJOR, MINOR or PATCH.
-//	currentMajor (string): Current release major version. I.e.: >2<.1.1.
-//	currentMinor (string): Current release minor version. I.e.: 2.>1<.1.
-//	currentPatch (string): Current release patch version. I.e.: 2.1.>1<.
+//	currentVersion (*semver.Version): Current release version. I.e.: 2.1.1.
 //
 // Returns:
 //
@@ -113,23 +100,35 @@ func (v *VersionControl) getUpgradeType(commitChangeType string) (string, error)
 //	I.e.:
 //	1 - If the current version is 2.1.1 and the update type is MAJOR it will return 3.0.0
 //	2 - If the current version is 2.1.1 and the update type is MINOR it will return 2.2.0
-//	1 - If the current version is 2.1.1 and the update type is PATCH it will return 2.1.2
-func (v *VersionControl) upgradeVersion(upgradeType string, currentMajor, currentMinor, currentPatch int) string {
-	versionPattern := "%d.%d.%d"
-	var newVersion string
+//	3 - If the current version is 2.1.1 and the update type is PATCH it will return 2.1.2
+//	4 - If the current version is 2.0.0-beta.3 and the update type is MINOR it will return 2.0.0, since 2.0.0 was not released yet
+func (v *VersionControl) upgradeVersion(upgradeType string, currentVersion *semver.Version) string {
+	// a pre-release precedes its normal version, which is released instead when the upgrade does not go beyond it
+	preRelease := currentVersion.IsPreRelease()
+	newVersion := semver.Version{Major: currentVersion.Major, Minor: currentVersion.Minor, Patch: currentVersion.Patch}
 
 	switch upgradeType {
 	case major:
-		v.log.Info(colorYellow+"%d"+colorReset+".0.0", currentMajor+1)
-		newVersion = fmt.Sprintf(versionPattern, currentMajor+1, 0, 0)
+		if !preRelease || newVersion.Minor != 0 || newVersion.Patch != 0 {
+			newVersion.Major++
+		}
+		newVersion.Minor, newVersion.Patch = 0, 0
+		v.log.Info("%d.0.0", newVersion.Major)
 	case minor:
-		v.log.Info("%d."+colorYellow+"%d"+colorReset+".0", currentMajor, currentMinor+1)
-		newVersion = fmt.Sprintf(versionPattern, currentMajor, currentMinor+1, 0)
+		if !preRelease || newVersion.Patch != 0 {
+			newVersion.Minor++
+		}
+		newVersion.Patch = 0
+		v.log.Info("%d.%d.0", newVersion.Major, newVersion.Minor)
 	case patch:
-		v.log.Info("%d.%d."+colorYellow+"%d"+colorReset, currentMajor, currentMinor, currentPatch+1)
-		newVersion = fmt.Sprintf(versionPattern, currentMajor, currentMinor, currentPatch+1)
+		if !preRelease {
+			newVersion.Patch++
+		}
+		v.log.Info("%d.%d.%d", newVersion.Major, newVersion.Minor, newVersion.Patch)
+	default:
+		return ""
 	}
-	return newVersion
+	return newVersion.String()
 }
 
 func (v *VersionControl) isFirstVersion(version string) bool {
@@ -158,25 +157,17 @@ func (v *VersionControl) GetNewVersion(commitMessage string, currentVersion stri
 	defer v.printElapsedTime("GetNewVersion")()
 	v.log.Info("generating new version from %s", currentVersion)
 
-	commitChangeType, err := v.commitType.GetCommitChangeType(commitMessage)
+	upgradeType, err := v.getCommitUpgradeType(commitMessage)
 	if err != nil {
-		return "", fmt.Errorf("error while finding commit change type within commit message due to: %w", err)
+		return "", err
 	}
 
-	curVersion, err := v.splitVersionMajorMinorPatch(currentVersion)
+	curVersion, err := semver.Parse(currentVersion)
 	if err != nil {
 		return "", fmt.Errorf("error while spliting version into MAJOR.MINOR.PATCH due to: %w", err)
 	}
-	currentMajor := curVersion[major]
-	currentMinor := curVersion[minor]
-	currentPatch := curVersion[patch]
 
-	upgradeType, err := v.getUpgradeType(commitChangeType)
-	if err != nil {
-		return "", fmt.Errorf("error while getting upgrade type due to: %w", err)
-	}
-
-	newVersion := v.upgradeVersion(upgradeType, currentMajor, currentMinor, currentPatch)
+	newVersion := v.upgradeVersion(upgradeType, curVersion)
 	if v.isFirstVersion(newVersion) {
 		return "1.0.0", nil
 	}
@@ -184,6 +175,28 @@ func (v *VersionControl) GetNewVersion(commitMessage string, currentVersion stri
 	return newVersion, nil
 }
 
+// GetUpgradeType returns the kind of upgrade the commit message triggers.
+// Args:
+//
+//	commitMessage (string): The commit message.
+//
+// Returns:
+//
+//	string: major, minor or patch. It returns none when the versioning must be skipped.
+//	error: It returns an error when the commit type is not found.
+func (v *VersionControl) GetUpgradeType(commitMessage string) (string, error) {
+	if v.MustSkipVersioning(commitMessage) {
+		return strings.ToLower(none), nil
+	}
+
+	upgradeType, err := v.getCommitUpgradeType(commitMessage)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.ToLower(upgradeType), nil
+}
+
 // hasStringInSlice aims to verify if a string is inside a slice of strings.
 // It requires a full match.
 // Args:
@@ -204,18 +217,23 @@ func hasStringInSlice(value string, slice []string) bool {
 }
 
 // MustSkip compare commit type with skip types (CommitTypeSkipVersioning) to avoid upgrading version.
+// Breaking changes are never skipped.
 // I.e.:
 //
 //	commitChangeType: [skip]
 //
 // Output: true
 func (v *VersionControl) MustSkipVersioning(commitMessage string) bool {
-	commitChangeType, err := v.commitType.GetCommitChangeType(commitMessage)
+	parsedCommit, err := v.commitType.ParseCommit(commitMessage)
 	if err != nil {
 		return true
 	}
 
-	return hasStringInSlice(commitChangeType, v.commitType.GetSkipVersioning())
+	if parsedCommit.Breaking {
+		return false
+	}
+
+	return hasStringInSlice(parsedCommit.Type, v.commitType.GetSkipVersioning())
 }
 
 // NewVersionControl is the version control constructor
@@ -172,3 +172,56 @@ func TestGetNewVersionAllMajorTypesSuccess(t *testing.T) {
 		tests.AssertEqualValues(t, expected, actualVersion)
 	}
 }
+
+func TestGetUpgradeTypeSuccess(t *testing.T) {
+	f := setup()
+	messages := map[string]string{
+		"breaking change(scope): this is the message": "major",
+		"feat(scope): this is the message":            "minor",
+		"fix: this is the message":                    "patch",
+		"skip: this is the message":                   "none",
+		"chore(scope): this is the message":           "none",
+	}
+
+	for message, expected := range messages {
+		actualUpgradeType, actualErr := f.versionControl.GetUpgradeType(message)
+		tests.AssertNoError(t, actualErr)
+		tests.AssertEqualValues(t, expected, actualUpgradeType)
+	}
+}
+
+func TestGetNewVersionBreakingChangeSuccess(t *testing.T) {
+	f := setup()
+	messages := []string{
+		"feat!: this is the message",
+		"fix(scope)!: this is the message",
+		"fix: this is the message\n\nBREAKING CHANGE: the config file format changed",
+		"refactor(scope): this is the message\n\nBREAKING-CHANGE: the config file format changed",
+		"chore!: this is the message",
+	}
+
+	for _, message := range messages {
+		actualVersion, actualErr := f.versionControl.GetNewVersion(message, "1.2.3")
+		tests.AssertNoError(t, actualErr)
+		tests.AssertEqualValues(t, "2.0.0", actualVersion)
+		tests.AssertFalse(t, f.versionControl.MustSkipVersioning(message))
+	}
+}
+
+func TestGetNewVersionFromPreReleaseSuccess(t *testing.T) {
+	f := setup()
+	expectedByMessage := map[string]map[string]string{
+		"2.0.0-beta.3":  {"bc: this is the message": "2.0.0", "feat: this is the message": "2.0.0", "fix: this is the message": "2.0.0"},
+		"1.2.0-rc.1":    {"bc: this is the message": "2.0.0", "feat: this is the message": "1.2.0", "fix: this is the message": "1.2.0"},
+		"1.2.3-rc.1":    {"bc: this is the message": "2.0.0", "feat: this is the message": "1.3.0", "fix: this is the message": "1.2.3"},
+		"1.2.3+build.5": {"fix: this is the message": "1.2.4"},
+	}
+
+	for currentVersion, expectedVersions := range expectedByMessage {
+		for message, expected := range expectedVersions {
+			actualVersion, actualErr := f.versionControl.GetNewVersion(message, currentVersion)
+			tests.AssertNoError(t, actualErr)
+			tests.AssertEqualValues(t, expected, actualVersion)
+		}
+	}
+}